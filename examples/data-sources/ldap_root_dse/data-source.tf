@@ -0,0 +1,2 @@
+data "ldap_root_dse" "example" {
+}
@@ -0,0 +1,2 @@
+data "ldap_schema" "example" {
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+	"strings"
+)
+
+// resultCodeExplanations describe the LDAP result codes usually caused by the configuration.
+var resultCodeExplanations = map[uint16]string{
+	ldap.LDAPResultNoSuchAttribute:           "The attribute or value doesn't exist in the entry.",
+	ldap.LDAPResultUndefinedAttributeType:    "The attribute type isn't defined in the schema of the server.",
+	ldap.LDAPResultInappropriateMatching:     "The attribute type has no matching rule for the requested comparison.",
+	ldap.LDAPResultConstraintViolation:       "A value violates a constraint of the server, e.g. a size limit or a password policy.",
+	ldap.LDAPResultAttributeOrValueExists:    "The attribute or value already exists in the entry.",
+	ldap.LDAPResultInvalidAttributeSyntax:    "A value doesn't match the syntax of its attribute type.",
+	ldap.LDAPResultNoSuchObject:              "The entry or one of its parent entries doesn't exist.",
+	ldap.LDAPResultInvalidDNSyntax:           "The DN isn't valid.",
+	ldap.LDAPResultInsufficientAccessRights:  "The bind DN isn't allowed to perform the operation.",
+	ldap.LDAPResultUnwillingToPerform:        "The server refuses to perform the operation, e.g. because of its configuration.",
+	ldap.LDAPResultNamingViolation:           "The DN violates the naming rules of the server, e.g. because the attribute of the RDN is missing in the entry.",
+	ldap.LDAPResultObjectClassViolation:      "The entry doesn't conform to its object classes, e.g. because a required attribute is missing or an attribute isn't allowed.",
+	ldap.LDAPResultNotAllowedOnNonLeaf:       "The entry still has child entries.",
+	ldap.LDAPResultNotAllowedOnRDN:           "The value is part of the RDN of the entry and can't be removed.",
+	ldap.LDAPResultEntryAlreadyExists:        "An entry with the DN already exists.",
+	ldap.LDAPResultObjectClassModsProhibited: "The object classes of the entry can't be modified.",
+}
+
+// attributeErrorPattern matches a server error message and extracts the name of the attribute it refers to.
+type attributeErrorPattern struct {
+	pattern *regexp.Regexp
+	// attributeGroup is the index of the subexpression containing the attribute type
+	attributeGroup int
+	// hint formats a hint from the subexpressions of the pattern
+	hint func(matches []string) string
+}
+
+// attributeErrorPatterns match the messages of OpenLDAP, 389 Directory Server and Active Directory.
+var attributeErrorPatterns = []attributeErrorPattern{
+	{
+		pattern:        regexp.MustCompile(`object class '([^']+)' requires attribute '([^']+)'`),
+		attributeGroup: 2,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("missing MUST attribute %s for class %s", matches[2], matches[1])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`missing attribute "([^"]+)" required by object class "([^"]+)"`),
+		attributeGroup: 1,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("missing MUST attribute %s for class %s", matches[1], matches[2])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`attribute ['"]([^'"]+)['"] not allowed`),
+		attributeGroup: 1,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("attribute %s isn't allowed by the object classes of the entry", matches[1])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`([\w;-]+): attribute type undefined`),
+		attributeGroup: 1,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("attribute type %s is unknown to the server, check it for typos", matches[1])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`([\w;-]+): value #\d+ invalid per syntax`),
+		attributeGroup: 1,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("a value of %s doesn't match the syntax of the attribute type", matches[1])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`attribute '([^']+)' cannot have multiple values`),
+		attributeGroup: 1,
+		hint: func(matches []string) string {
+			return fmt.Sprintf("%s is a SINGLE-VALUE attribute and only accepts one value", matches[1])
+		},
+	},
+	{
+		pattern:        regexp.MustCompile(`(?i)Att [0-9a-f]+ \(([\w-]+)\)`),
+		attributeGroup: 1,
+	},
+}
+
+// ResultCodeHints give advice for result codes which aren't specific to an attribute. They depend on the operation,
+// so they are passed by the caller of AddLDAPError.
+type ResultCodeHints map[uint16]string
+
+// AddLDAPError adds an error diagnostic explaining an error returned by the LDAP server. If the error refers to an
+// attribute of the given attributes, the diagnostic points to it. The hints of the result code are added unless the
+// server message allows a more specific hint.
+func AddLDAPError(diagnostics *diag.Diagnostics, summary string, err error, attributes types.Map, hints ...ResultCodeHints) {
+	var ldapError *ldap.Error
+	if !errors.As(err, &ldapError) {
+		diagnostics.AddError(summary, fmt.Sprintf("LDAP server reported: %s", err))
+		return
+	}
+
+	message := ldapError.Err.Error()
+	detail := fmt.Sprintf(
+		"LDAP server reported: %s\n\nResult code: %s (%d)",
+		message,
+		ldap.LDAPResultCodeMap[ldapError.ResultCode],
+		ldapError.ResultCode,
+	)
+	if explanation, ok := resultCodeExplanations[ldapError.ResultCode]; ok {
+		detail += fmt.Sprintf("\n%s", explanation)
+	}
+	if ldapError.MatchedDN != "" {
+		detail += fmt.Sprintf("\nMatched DN: %s", ldapError.MatchedDN)
+	}
+
+	attributeType := ""
+	hint := ""
+	for _, h := range hints {
+		if h, ok := h[ldapError.ResultCode]; ok {
+			hint = h
+		}
+	}
+	for _, p := range attributeErrorPatterns {
+		if matches := p.pattern.FindStringSubmatch(message); matches != nil {
+			attributeType = matches[p.attributeGroup]
+			if p.hint != nil {
+				hint = p.hint(matches)
+			}
+			break
+		}
+	}
+	if hint != "" {
+		detail += fmt.Sprintf("\nHint: %s", hint)
+	}
+
+	if strings.EqualFold(attributeType, "objectClass") {
+		diagnostics.AddAttributeError(path.Root("object_classes"), summary, detail)
+		return
+	}
+	for configuredType := range attributes.Elements() {
+		if attributeType != "" && strings.EqualFold(configuredType, attributeType) {
+			diagnostics.AddAttributeError(path.Root("attributes").AtMapKey(configuredType), summary, detail)
+			return
+		}
+	}
+	diagnostics.AddError(summary, detail)
+}
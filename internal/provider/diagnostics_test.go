@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"errors"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAddLDAPError(t *testing.T) {
+	attributes := types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+		"cn":    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("alice")}),
+		"Email": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("alice@example.com")}),
+	})
+	tests := []struct {
+		err    error
+		path   path.Path
+		detail []string
+	}{
+		{
+			ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("object class 'person' requires attribute 'sn'")),
+			path.Empty(),
+			[]string{"Result code: Object Class Violation (65)", "Hint: missing MUST attribute sn for class person"},
+		},
+		{
+			ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("attribute 'email' not allowed")),
+			path.Root("attributes").AtMapKey("Email"),
+			[]string{"Hint: attribute email isn't allowed by the object classes of the entry"},
+		},
+		{
+			ldap.NewError(ldap.LDAPResultUndefinedAttributeType, errors.New("email: attribute type undefined")),
+			path.Root("attributes").AtMapKey("Email"),
+			[]string{"Result code: Undefined Attribute Type (17)"},
+		},
+		{
+			ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("missing attribute \"cn\" required by object class \"person\"")),
+			path.Root("attributes").AtMapKey("cn"),
+			[]string{"Hint: missing MUST attribute cn for class person"},
+		},
+		{
+			ldap.NewError(ldap.LDAPResultObjectClassViolation, errors.New("objectClass: value #0 invalid per syntax")),
+			path.Root("object_classes"),
+			[]string{"Hint: a value of objectClass doesn't match the syntax of the attribute type"},
+		},
+		{
+			&ldap.Error{ResultCode: ldap.LDAPResultNoSuchObject, MatchedDN: "dc=example,dc=com", Err: errors.New("")},
+			path.Empty(),
+			[]string{"Matched DN: dc=example,dc=com", "Hint: set create_parents"},
+		},
+		{
+			ldap.NewError(ldap.LDAPResultEntryAlreadyExists, errors.New("")),
+			path.Empty(),
+			[]string{"Hint: import the entry"},
+		},
+		{
+			errors.New("connection closed"),
+			path.Empty(),
+			[]string{"LDAP server reported: connection closed"},
+		},
+	}
+	for _, test := range tests {
+		var diagnostics diag.Diagnostics
+		AddLDAPError(&diagnostics, "Can not add resource", test.err, attributes, createHints)
+		if assert.Len(t, diagnostics, 1, test.err.Error()) {
+			d := diagnostics[0]
+			if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+				assert.Equal(t, test.path, withPath.Path(), test.err.Error())
+			} else {
+				assert.Equal(t, path.Empty(), test.path, test.err.Error())
+			}
+			for _, detail := range test.detail {
+				assert.Contains(t, d.Detail(), detail, test.err.Error())
+			}
+		}
+	}
+}
+
+func TestAddLDAPErrorWithoutHints(t *testing.T) {
+	var diagnostics diag.Diagnostics
+	err := &ldap.Error{ResultCode: ldap.LDAPResultNoSuchObject, MatchedDN: "dc=example,dc=com", Err: errors.New("")}
+	AddLDAPError(&diagnostics, "Can not read entry", err, types.MapNull(types.ListType{ElemType: types.StringType}))
+	if assert.Len(t, diagnostics, 1) {
+		assert.Contains(t, diagnostics[0].Detail(), "Matched DN: dc=example,dc=com")
+		assert.NotContains(t, diagnostics[0].Detail(), "Hint:")
+	}
+}
@@ -3,10 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -19,7 +20,7 @@ func NewLDAPObjectDataSource() datasource.DataSource {
 }
 
 type LDAPObjectDataSource struct {
-	conn *ldap.Conn
+	conn Searcher
 }
 
 type LDAPObjectDatasourceModel struct {
@@ -28,6 +29,8 @@ type LDAPObjectDatasourceModel struct {
 	ObjectClasses        types.List   `tfsdk:"object_classes"`
 	Attributes           types.Map    `tfsdk:"attributes"`
 	AdditionalAttributes types.Set    `tfsdk:"additional_attributes"`
+	RequestedAttributes  types.Set    `tfsdk:"requested_attributes"`
+	DerefAliases         types.String `tfsdk:"deref_aliases"`
 }
 
 func (L *LDAPObjectDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
@@ -46,6 +49,20 @@ func (L *LDAPObjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				MarkdownDescription: "DN of this ldap object",
 				Required:            true,
 			},
+			"requested_attributes": schema.SetAttribute{
+				MarkdownDescription: "The attribute types to request instead of all user attributes (`*`) " +
+					"in addition to `additional_attributes`. Use `[\"1.1\"]` to request no attributes at all",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"deref_aliases": schema.StringAttribute{
+				MarkdownDescription: "When to dereference aliases: `never` (default), `searching` (only below the base " +
+					"DN), `finding` (only the base DN) or `always`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("never", "searching", "finding", "always"),
+				},
+			},
 			"additional_attributes": schema.SetAttribute{
 				MarkdownDescription: "Any additional attributes to request, such as constructed attributes",
 				Optional:            true,
@@ -70,15 +87,15 @@ func (L *LDAPObjectDataSource) Configure(_ context.Context, request datasource.C
 		return
 	}
 
-	if conn, ok := request.ProviderData.(*ldap.Conn); !ok {
+	if providerData, ok := request.ProviderData.(*LDAPProviderData); !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Datasource Configure Type",
-			fmt.Sprintf("Expected *ldap.Conn, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *LDAPProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	} else {
-		L.conn = conn
+		L.conn = providerData.Searcher()
 	}
 }
 
@@ -100,12 +117,10 @@ func (L *LDAPObjectDataSource) Read(ctx context.Context, request datasource.Read
 
 	var additionalAttributes []string
 	response.Diagnostics.Append(data.AdditionalAttributes.ElementsAs(ctx, &additionalAttributes, false)...)
+	attributeTypes := RequestedAttributeTypes(ctx, data.RequestedAttributes, additionalAttributes, &response.Diagnostics)
 
-	if entry, err := GetEntry(L.conn, data.DN.ValueString(), append(additionalAttributes, "*")...); err != nil {
-		response.Diagnostics.AddError(
-			"Can not read entry",
-			err.Error(),
-		)
+	if entry, err := GetEntryWithDerefAliases(L.conn, data.DN.ValueString(), DerefAliases[data.DerefAliases.ValueString()], attributeTypes...); err != nil {
+		AddLDAPError(&response.Diagnostics, "Can not read entry", err, types.MapNull(types.ListType{ElemType: types.StringType}))
 	} else {
 		response.State.SetAttribute(ctx, path.Root("dn"), entry.DN)
 		ctx = MaskAttributesFromArray(ctx, entry.Attributes)
@@ -25,6 +25,32 @@ func TestLDAPObjectDatasource(t *testing.T) {
 	})
 }
 
+func TestLDAPObjectDatasourceRequestedAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceRequestedAttributes,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_object.test", "attributes.%", "1"),
+					resource.TestCheckResourceAttr("data.ldap_object.test", "attributes.dc.0", "example"),
+					resource.TestCheckNoResourceAttr("data.ldap_object.test", "object_classes.#"),
+				),
+			},
+		},
+	})
+}
+
+const testDataSourceRequestedAttributes = `
+data "ldap_object" "test" {
+	dn = "dc=example,dc=com"
+	requested_attributes = ["dc"]
+	deref_aliases = "finding"
+}`
+
 const testDataSource = `
 data "ldap_object" "test" {
 	dn = "dc=example,dc=com"
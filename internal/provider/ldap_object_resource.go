@@ -5,28 +5,45 @@ import (
 	"errors"
 	"fmt"
 	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/thoas/go-funk"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 )
 
 var _ resource.Resource = &LDAPObjectResource{}
 var _ resource.ResourceWithImportState = &LDAPObjectResource{}
 var _ resource.ResourceWithModifyPlan = &LDAPObjectResource{}
 var _ resource.ResourceWithConfigure = &LDAPObjectResource{}
+var _ resource.ResourceWithValidateConfig = &LDAPObjectResource{}
 
 func NewLDAPObjectResource() resource.Resource {
 	return &LDAPObjectResource{}
 }
 
 type LDAPObjectResource struct {
-	conn *ldap.Conn
+	conn                 *ldap.Conn
+	defaultIgnoreChanges []string
+	backupDir            string
+	validateSchema       bool
+	schema               func() (*Schema, error)
+	defaultBaseDN        func() (string, error)
 }
 
 type LDAPObjectResourceModel struct {
@@ -35,15 +52,51 @@ type LDAPObjectResourceModel struct {
 	ObjectClasses types.List   `tfsdk:"object_classes"`
 	Attributes    types.Map    `tfsdk:"attributes"`
 	IgnoreChanges types.List   `tfsdk:"ignore_changes"`
+	ManagedOnly   types.Bool   `tfsdk:"managed_attributes_only"`
+	InitialAttrs  types.Map    `tfsdk:"initial_attributes"`
+	FetchOpAttrs  types.List   `tfsdk:"fetch_operational_attributes"`
+	OpAttrs       types.Map    `tfsdk:"operational_attributes"`
+	EntryUUID     types.String `tfsdk:"entry_uuid"`
+	OnConflict    types.String `tfsdk:"on_conflict"`
+	CreateParents types.Bool   `tfsdk:"create_parents"`
+	ParentClasses types.Map    `tfsdk:"parent_object_classes"`
+	CreatedParent types.List   `tfsdk:"created_parents"`
+	DeleteMode    types.String `tfsdk:"delete_mode"`
+	DeleteLimit   types.Int64  `tfsdk:"subtree_delete_limit"`
+	RDN           types.String `tfsdk:"rdn"`
+	ParentDN      types.String `tfsdk:"parent_dn"`
 }
 
+// backupFilenameReplacer matches the characters of a DN which are replaced in backup filenames.
+var backupFilenameReplacer = regexp.MustCompile(`[^A-Za-z0-9=,.-]+`)
+
+// createHints give advice for result codes returned when creating an entry.
+var createHints = ResultCodeHints{
+	ldap.LDAPResultNoSuchObject:       "set create_parents to create missing parent entries",
+	ldap.LDAPResultEntryAlreadyExists: "import the entry or set on_conflict = \"adopt\" to take it over",
+}
+
+// deleteHints give advice for result codes returned when deleting an entry.
+var deleteHints = ResultCodeHints{
+	ldap.LDAPResultNotAllowedOnNonLeaf: "set delete_mode = \"subtree\" to delete the entry including its child entries",
+}
+
+// defaultParentObjectClasses are the object classes of created parent entries if the type of their RDN isn't
+// configured in parent_object_classes.
+var defaultParentObjectClasses = []string{"organizationalUnit"}
+
+// defaultOperationalAttributes are the operational attributes fetched if fetch_operational_attributes isn't set.
+var defaultOperationalAttributes = []string{"entryUUID", "objectGUID", "createTimestamp", "modifyTimestamp", "creatorsName"}
+
 func (L *LDAPObjectResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_object"
 }
 
 func (L *LDAPObjectResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
-		MarkdownDescription: "Generic LDAP object resource",
+		MarkdownDescription: "Generic LDAP object resource. Large multi-valued attributes the server only returns in " +
+			"ranges, like `member;range=0-1499` in Active Directory, are read completely and merged into one " +
+			"attribute without the range option",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -53,8 +106,27 @@ func (L *LDAPObjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 			"dn": schema.StringAttribute{
-				MarkdownDescription: "DN of this ldap object",
-				Required:            true,
+				MarkdownDescription: "DN of this ldap object. Computed if `rdn` is set",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("rdn")),
+				},
+			},
+			"rdn": schema.StringAttribute{
+				MarkdownDescription: "RDN of this ldap object in the form `type=value` as an alternative to `dn`. The " +
+					"value is escaped, so it must not be escaped in the configuration",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^=]+=.+$`), "must have the form type=value"),
+				},
+			},
+			"parent_dn": schema.StringAttribute{
+				MarkdownDescription: "DN of the parent entry if `rdn` is set. Defaults to the base DN of the provider",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("rdn")),
+				},
 			},
 			"object_classes": schema.ListAttribute{
 				MarkdownDescription: "A list of classes this object implements",
@@ -66,10 +138,97 @@ func (L *LDAPObjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:            true,
 				ElementType:         types.ListType{ElemType: types.StringType},
 			},
-			"ignore_changes": schema.ListAttribute{
-				MarkdownDescription: "A list of types for which changes are ignored",
+			"initial_attributes": schema.MapAttribute{
+				MarkdownDescription: "Attributes which are only set when the entry is created (e.g. an initial password) or " +
+					"recreated because the server refuses to move it to a new DN. " +
+					"They are neither compared nor read back afterwards, and changing them doesn't update the entry",
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				ElementType: types.ListType{ElemType: types.StringType},
+				PlanModifiers: []planmodifier.Map{
+					UseStateAfterCreate(),
+				},
+			},
+			"fetch_operational_attributes": schema.ListAttribute{
+				MarkdownDescription: "A list of operational attributes to fetch into `operational_attributes`. Defaults to " +
+					"entryUUID, objectGUID, createTimestamp, modifyTimestamp and creatorsName",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"operational_attributes": schema.MapAttribute{
+				MarkdownDescription: "Operational attributes generated by the server, as requested by `fetch_operational_attributes`. " +
+					"Binary values are base64 encoded",
+				Computed:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"on_conflict": schema.StringAttribute{
+				MarkdownDescription: "What to do if the entry already exists when it is created: `fail` (default) or " +
+					"`adopt` the entry by modifying it to match the configuration",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("fail", "adopt"),
+				},
+			},
+			"create_parents": schema.BoolAttribute{
+				MarkdownDescription: "Create missing parent entries of the DN. They are removed on destroy if they are empty",
 				Optional:            true,
+			},
+			"parent_object_classes": schema.MapAttribute{
+				MarkdownDescription: "The object classes of created parent entries by the attribute type of their RDN " +
+					"(e.g. `{ o = [\"organization\"] }`). Defaults to organizationalUnit",
+				Optional:    true,
+				ElementType: types.ListType{ElemType: types.StringType},
+			},
+			"created_parents": schema.ListAttribute{
+				MarkdownDescription: "The DNs of the parent entries created for this entry",
+				Computed:            true,
 				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"delete_mode": schema.StringAttribute{
+				MarkdownDescription: "How the entry is deleted on destroy: `entry` (default) deletes only the entry, " +
+					"`subtree` deletes the entry including all entries below it, `abandon` keeps the entry and only " +
+					"removes it from the state, `managed_attributes` keeps the entry and removes the attribute values " +
+					"set by this resource. It keeps the values of the RDN and the last values of attributes required " +
+					"by the object classes of the entry",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("entry", "subtree", "abandon", "managed_attributes"),
+				},
+			},
+			"subtree_delete_limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of entries (including the entry itself) removed by the `subtree` " +
+					"delete mode. Required for it",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"entry_uuid": schema.StringAttribute{
+				MarkdownDescription: "Stable identifier of the entry (entryUUID, nsUniqueId or objectGUID) used to follow the " +
+					"entry if it was moved outside of Terraform",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ignore_changes": schema.ListAttribute{
+				MarkdownDescription: "A list of types for which changes are ignored. Entries can be glob patterns like `samba*` " +
+					"or regular expressions enclosed in slashes like `/x-acme-.*/`, which have to match the whole type. " +
+					"The configuration isn't available while importing, so only `default_ignore_changes` of the provider " +
+					"apply to an import. Attributes matching `ignore_changes` are still imported, but never changed",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(AttributePattern()),
+				},
+			},
+			"managed_attributes_only": schema.BoolAttribute{
+				MarkdownDescription: "Only read, compare and write the attribute types set in `attributes` and leave all other attributes of the entry untouched",
+				Optional:            true,
 			},
 		},
 	}
@@ -80,15 +239,20 @@ func (L *LDAPObjectResource) Configure(_ context.Context, request resource.Confi
 		return
 	}
 
-	if conn, ok := request.ProviderData.(*ldap.Conn); !ok {
+	if providerData, ok := request.ProviderData.(*LDAPProviderData); !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *ldap.Conn, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *LDAPProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	} else {
-		L.conn = conn
+		L.conn = providerData.Conn
+		L.defaultIgnoreChanges = providerData.DefaultIgnoreChanges
+		L.backupDir = providerData.BackupDir
+		L.validateSchema = providerData.ValidateSchema
+		L.schema = providerData.Schema
+		L.defaultBaseDN = providerData.DefaultBaseDN
 	}
 }
 
@@ -100,13 +264,17 @@ func (L *LDAPObjectResource) Create(ctx context.Context, request resource.Create
 	}
 
 	if err := L.addLdapEntry(ctx, data, &response.Diagnostics); err != nil {
-		response.Diagnostics.AddError(
-			"Can not add resource",
-			fmt.Sprintf("LDAP server reported: %s", err),
-		)
-		return
+		if !ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) || data.OnConflict.ValueString() != "adopt" {
+			AddLDAPError(&response.Diagnostics, "Can not add resource", err, data.Attributes, createHints)
+			return
+		}
+		if err := L.adoptLdapEntry(ctx, data, &response.Diagnostics); err != nil {
+			AddLDAPError(&response.Diagnostics, "Can not adopt existing entry", err, data.Attributes)
+			return
+		}
 	}
-	data.ID = data.DN
+	data.ID = types.StringValue(data.DN.ValueString())
+	L.readGeneratedAttributes(ctx, data, &response.Diagnostics)
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
@@ -117,23 +285,58 @@ func (L *LDAPObjectResource) Read(ctx context.Context, request resource.ReadRequ
 		return
 	}
 
+	operationalAttributeTypes := L.operationalAttributeTypes(ctx, data, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	attributeTypes := append(append(operationalAttributeTypes, UUIDAttributeTypes...), "*")
+
 	tflog.Debug(ctx, "Reading entry", map[string]interface{}{"dn": data.DN.ValueString()})
-	if entry, err := GetEntry(L.conn, data.DN.ValueString()); err != nil {
-		response.Diagnostics.AddError(
-			"Can not read entry",
-			err.Error(),
-		)
+	entry, err := GetEntry(L.conn, data.DN.ValueString(), attributeTypes...)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) && data.EntryUUID.ValueString() != "" {
+		tflog.Info(ctx, "Entry not found, searching it by its UUID", map[string]interface{}{
+			"dn":   data.DN.ValueString(),
+			"uuid": data.EntryUUID.ValueString(),
+		})
+		if entry, err = FindEntryByUUID(L.conn, data.EntryUUID.ValueString(), attributeTypes...); err == nil {
+			tflog.Warn(ctx, "Entry was moved outside of Terraform", map[string]interface{}{
+				"oldDn": data.DN.ValueString(),
+				"dn":    entry.DN,
+			})
+			response.State.SetAttribute(ctx, path.Root("id"), entry.DN)
+		}
+	}
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			tflog.Warn(ctx, "Entry not found, removing it from the state", map[string]interface{}{"dn": data.DN.ValueString()})
+			response.State.RemoveResource(ctx)
+			return
+		}
+		AddLDAPError(&response.Diagnostics, "Can not read entry", err, data.Attributes)
 	} else {
-		response.State.SetAttribute(ctx, path.Root("dn"), entry.DN)
+		if !SameDN(entry.DN, data.DN.ValueString()) {
+			response.State.SetAttribute(ctx, path.Root("dn"), entry.DN)
+		}
 		ctx = MaskAttributesFromArray(ctx, entry.Attributes)
+		operationalAttributes := make(map[string][]string)
 		for _, attribute := range entry.Attributes {
 			if attribute.Name == "objectClass" {
 				response.State.SetAttribute(ctx, path.Root("object_classes"), attribute.Values)
-			} else if !L.isIgnored(ctx, attribute.Name, data, response.Diagnostics) {
+			} else if ContainsAttributeType(operationalAttributeTypes, attribute.Name) {
+				operationalAttributes[attribute.Name] = PrintableValues(attribute)
+			} else if ContainsAttributeType(UUIDAttributeTypes, attribute.Name) {
+				continue
+			} else if !L.isIgnored(ctx, attribute.Name, data, response.Diagnostics) && L.isManaged(ctx, attribute.Name, data, response.Diagnostics) {
 				response.State.SetAttribute(ctx, path.Root("attributes").AtMapKey(attribute.Name), attribute.Values)
 			}
 		}
 
+		response.State.SetAttribute(ctx, path.Root("operational_attributes"), operationalAttributes)
+		if uuid := EntryUUID(entry); uuid != "" {
+			response.State.SetAttribute(ctx, path.Root("entry_uuid"), uuid)
+		}
+
 		tflog.Debug(ctx, "Read entry", map[string]interface{}{"entry": ToLDIF(entry)})
 	}
 }
@@ -147,103 +350,56 @@ func (L *LDAPObjectResource) Update(ctx context.Context, request resource.Update
 		return
 	}
 
-	// Recreate object if DN changed
+	// Move the entry if the DN changed and recreate it if the server refuses to move it
+	recreate := false
 	if stateData.DN.ValueString() != planData.DN.ValueString() {
-		tflog.Warn(ctx, "Recreating entry because the DN changed", map[string]interface{}{
+		tflog.Info(ctx, "Moving entry because the DN changed", map[string]interface{}{
 			"oldDn": stateData.DN.ValueString(),
 			"dn":    planData.DN.ValueString(),
 		})
 
-		if err := L.conn.Del(ldap.NewDelRequest(stateData.DN.ValueString(), []ldap.Control{})); err != nil {
+		if err := L.moveLdapEntry(ctx, stateData, planData, &response.Diagnostics); err != nil {
+			if !isMoveRefused(err) {
+				AddLDAPError(&response.Diagnostics, "Can not move entry", err, planData.Attributes)
+				return
+			}
+			tflog.Warn(ctx, "Recreating entry because the server refused to move it", map[string]interface{}{
+				"oldDn": stateData.DN.ValueString(),
+				"dn":    planData.DN.ValueString(),
+				"error": err.Error(),
+			})
+			recreate = true
+		}
+	}
+
+	if recreate {
+		if err := L.backupEntries(ctx, stateData, ldap.ScopeBaseObject, &response.Diagnostics); err != nil {
 			response.Diagnostics.AddError(
-				"Can not delete old DN entry",
-				fmt.Sprintf("Trying to delete entry of old DN returned: %s", err),
+				"Can not back up entry",
+				fmt.Sprintf("Backing up the entry of the old DN failed: %s", err),
 			)
 			return
 		}
+		if err := L.conn.Del(ldap.NewDelRequest(stateData.DN.ValueString(), []ldap.Control{})); err != nil {
+			AddLDAPError(&response.Diagnostics, "Can not delete old DN entry", err, stateData.Attributes, deleteHints)
+			return
+		}
+		L.deleteEmptyParents(ctx, stateData, &response.Diagnostics)
 		if err := L.addLdapEntry(ctx, planData, &response.Diagnostics); err != nil {
-			response.Diagnostics.AddError(
-				"Can not add resource",
-				fmt.Sprintf("LDAP server reported: %s", err),
-			)
+			AddLDAPError(&response.Diagnostics, "Can not add resource", err, planData.Attributes, createHints)
 			return
 		}
 	} else {
-		r := ldap.NewModifyRequest(planData.DN.ValueString(), []ldap.Control{})
-
-		var stateObjectClasses []string
-		response.Diagnostics.Append(stateData.ObjectClasses.ElementsAs(ctx, &stateObjectClasses, false)...)
-		var planObjectClasses []string
-		response.Diagnostics.Append(planData.ObjectClasses.ElementsAs(ctx, &planObjectClasses, false)...)
-
-		var classesToAdd []string
-		for _, class := range planObjectClasses {
-			if funk.IndexOf(stateObjectClasses, class) == -1 {
-				classesToAdd = append(classesToAdd, class)
-			}
+		if planData.CreatedParent.IsUnknown() {
+			planData.CreatedParent = stateData.CreatedParent
 		}
-
-		if len(classesToAdd) > 0 {
-			r.Add("objectClass", classesToAdd)
-		}
-
-		var stateAttributes map[string][]string
-		response.Diagnostics.Append(stateData.Attributes.ElementsAs(ctx, &stateAttributes, false)...)
-		var planAttributes map[string][]string
-		response.Diagnostics.Append(planData.Attributes.ElementsAs(ctx, &planAttributes, false)...)
-
-		ctx = MaskAttributes(ctx, stateAttributes)
-		for attributeType, stateValues := range stateAttributes {
-			if L.isIgnored(ctx, attributeType, stateData, response.Diagnostics) {
-				continue
-			}
-			// state attribute is in the plan, compare the values
-			if planValues, exists := planAttributes[attributeType]; exists {
-				valuesChanged := false
-				for _, stateValue := range stateValues {
-					if !funk.ContainsString(planValues, stateValue) {
-						valuesChanged = true
-					}
-				}
-				for _, planValue := range planValues {
-					if !funk.ContainsString(stateValues, planValue) {
-						valuesChanged = true
-					}
-				}
-				if valuesChanged {
-					tflog.Debug(ctx, "Changing attribute", map[string]interface{}{
-						"type":   attributeType,
-						"values": planValues,
-					})
-					r.Replace(attributeType, planValues)
-				}
-			} else {
-				tflog.Debug(ctx, "Removing attribute", map[string]interface{}{
-					"type": attributeType,
-				})
-				r.Delete(attributeType, []string{})
-			}
-		}
-		for attributeType, values := range planAttributes {
-			if L.isIgnored(ctx, attributeType, planData, response.Diagnostics) {
-				continue
-			}
-			if _, exists := stateAttributes[attributeType]; !exists {
-				tflog.Debug(ctx, "Adding attribute", map[string]interface{}{
-					"type": attributeType,
-				})
-				r.Add(attributeType, values)
-			}
-		}
-		if err := L.conn.Modify(r); err != nil {
-			response.Diagnostics.AddError(
-				"Can not modify entry",
-				fmt.Sprintf("LDAP server reported: %s", err),
-			)
+		if err := L.modifyLdapEntry(ctx, stateData, planData, &response.Diagnostics); err != nil {
+			AddLDAPError(&response.Diagnostics, "Can not modify entry", err, planData.Attributes)
 			return
 		}
 	}
-	planData.ID = planData.DN
+	planData.ID = types.StringValue(planData.DN.ValueString())
+	L.readGeneratedAttributes(ctx, planData, &response.Diagnostics)
 	response.Diagnostics.Append(response.State.Set(ctx, &planData)...)
 }
 
@@ -254,31 +410,82 @@ func (L *LDAPObjectResource) Delete(ctx context.Context, request resource.Delete
 		return
 	}
 
-	tflog.Debug(ctx, "Deleting entry", map[string]interface{}{"dn": stateData.DN.ValueString()})
-	if err := L.conn.Del(ldap.NewDelRequest(stateData.DN.ValueString(), []ldap.Control{})); err != nil {
+	if stateData.DeleteMode.ValueString() == "abandon" {
+		tflog.Info(ctx, "Abandoning entry", map[string]interface{}{"dn": stateData.DN.ValueString()})
+		return
+	}
+
+	backupScope := ldap.ScopeBaseObject
+	if stateData.DeleteMode.ValueString() == "subtree" {
+		backupScope = ldap.ScopeWholeSubtree
+	}
+	if err := L.backupEntries(ctx, stateData, backupScope, &response.Diagnostics); err != nil {
 		response.Diagnostics.AddError(
-			"Can not delete entry",
-			fmt.Sprintf("Trying to delete entry returned: %s", err),
+			"Can not back up entry",
+			fmt.Sprintf("Backing up the entry before deleting it failed: %s", err),
 		)
 		return
 	}
+
+	if stateData.DeleteMode.ValueString() == "managed_attributes" {
+		tflog.Debug(ctx, "Deleting managed attributes of entry", map[string]interface{}{"dn": stateData.DN.ValueString()})
+		if err := L.deleteManagedAttributes(ctx, stateData, &response.Diagnostics); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			AddLDAPError(&response.Diagnostics, "Can not delete managed attributes", err, stateData.Attributes)
+		}
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting entry", map[string]interface{}{"dn": stateData.DN.ValueString()})
+	var err error
+	if stateData.DeleteMode.ValueString() == "subtree" {
+		err = L.deleteSubtree(ctx, stateData)
+	} else {
+		err = L.conn.Del(ldap.NewDelRequest(stateData.DN.ValueString(), []ldap.Control{}))
+	}
+	if err != nil {
+		if !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			AddLDAPError(&response.Diagnostics, "Can not delete entry", err, stateData.Attributes, deleteHints)
+			return
+		}
+		tflog.Warn(ctx, "Entry was already deleted", map[string]interface{}{"dn": stateData.DN.ValueString()})
+	}
+	L.deleteEmptyParents(ctx, stateData, &response.Diagnostics)
+}
+
+func (L *LDAPObjectResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data *LDAPObjectResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeleteMode.ValueString() == "subtree" && data.DeleteLimit.IsNull() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("subtree_delete_limit"),
+			"Missing subtree delete limit",
+			"The subtree delete mode requires subtree_delete_limit to guard against deleting more entries than expected",
+		)
+	}
 }
 
 func (L *LDAPObjectResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
 	tflog.Info(ctx, "Importing entry", map[string]interface{}{"dn": request.ID})
 	if entry, err := GetEntry(L.conn, request.ID); err != nil {
-		response.Diagnostics.AddError(
-			"Can not read entry",
-			err.Error(),
-		)
+		AddLDAPError(&response.Diagnostics, "Can not read entry", err, types.MapNull(types.ListType{ElemType: types.StringType}))
 	} else {
 		resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
 		ctx = MaskAttributesFromArray(ctx, entry.Attributes)
 		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("dn"), entry.DN)...)
+		// The configuration of the resource isn't available while importing, so only the provider's
+		// default_ignore_changes apply. ModifyPlan keeps the values of attributes matching the resource's
+		// ignore_changes, so they don't cause changes after the import.
+		data := &LDAPObjectResourceModel{
+			IgnoreChanges: types.ListNull(types.StringType),
+		}
 		for _, attribute := range entry.Attributes {
 			if attribute.Name == "objectClass" {
 				response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("object_classes"), attribute.Values)...)
-			} else {
+			} else if !L.isIgnored(ctx, attribute.Name, data, response.Diagnostics) {
 				response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("attributes").AtMapKey(attribute.Name), attribute.Values)...)
 			}
 		}
@@ -294,6 +501,16 @@ func (L *LDAPObjectResource) ModifyPlan(ctx context.Context, request resource.Mo
 
 	response.Diagnostics.Append(request.State.Get(ctx, &stateData)...)
 	response.Diagnostics.Append(request.Plan.Get(ctx, &planData)...)
+	if planData != nil && !planData.RDN.IsNull() {
+		planData.DN = L.planDN(ctx, planData, &response.Diagnostics)
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("dn"), planData.DN)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+	if planData != nil && L.validateSchema && L.schema != nil {
+		L.validateAgainstSchema(ctx, planData, &response.Diagnostics)
+	}
 	if stateData == nil || planData == nil {
 		// don't ignore any attributes on create and delete
 		return
@@ -301,6 +518,8 @@ func (L *LDAPObjectResource) ModifyPlan(ctx context.Context, request resource.Mo
 
 	if stateData != nil && planData != nil && stateData.DN != planData.DN {
 		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("entry_uuid"), types.StringUnknown())...)
+		response.Diagnostics.Append(response.Plan.SetAttribute(ctx, path.Root("created_parents"), types.ListUnknown(types.StringType))...)
 		if response.Diagnostics.HasError() {
 			return
 		}
@@ -328,6 +547,150 @@ func (L *LDAPObjectResource) ModifyPlan(ctx context.Context, request resource.Mo
 	}
 }
 
+// planDN computes the DN of the resource from its rdn and parent_dn.
+func (L *LDAPObjectResource) planDN(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) types.String {
+	if data.RDN.IsUnknown() || data.ParentDN.IsUnknown() {
+		return types.StringUnknown()
+	}
+	parentDN := data.ParentDN.ValueString()
+	if data.ParentDN.IsNull() && L.defaultBaseDN != nil {
+		baseDN, err := L.defaultBaseDN()
+		if err != nil {
+			diagnostics.AddAttributeError(
+				path.Root("parent_dn"),
+				"Can not determine base DN",
+				fmt.Sprintf("Discovering the base DN of the server failed, set parent_dn instead: %s", err),
+			)
+			return types.StringUnknown()
+		}
+		parentDN = baseDN
+	}
+	dn, err := BuildDN(data.RDN.ValueString(), parentDN)
+	if err != nil {
+		diagnostics.AddAttributeError(path.Root("rdn"), "Invalid RDN", err.Error())
+		return types.StringUnknown()
+	}
+	tflog.Debug(ctx, "Computed DN", map[string]interface{}{"rdn": data.RDN.ValueString(), "dn": dn})
+	return types.StringValue(dn)
+}
+
+// validateAgainstSchema checks the object classes and attributes of the plan against the subschema of the server.
+func (L *LDAPObjectResource) validateAgainstSchema(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) {
+	if !IsFullyKnown(ctx, data.ObjectClasses) || !IsFullyKnown(ctx, data.Attributes) || !IsFullyKnown(ctx, data.InitialAttrs) {
+		return
+	}
+	s, err := L.schema()
+	if err != nil {
+		tflog.Warn(ctx, "Can not read the schema of the server, skipping schema validation", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	var classNames []string
+	diagnostics.Append(data.ObjectClasses.ElementsAs(ctx, &classNames, false)...)
+	var classes []*ObjectClass
+	allowAll := false
+	for _, name := range classNames {
+		if c := s.ObjectClass(name); c == nil {
+			diagnostics.AddAttributeError(
+				path.Root("object_classes"),
+				"Unknown object class",
+				fmt.Sprintf("The object class %s isn't defined in the schema of the server", name),
+			)
+		} else {
+			classes = append(classes, c)
+			if funk.Contains(c.Names, "extensibleObject") {
+				allowAll = true
+			}
+		}
+	}
+	if diagnostics.HasError() {
+		return
+	}
+	must, may := s.ClassAttributeTypes(classes)
+	isOneOf := func(a *AttributeType, attributeTypes []*AttributeType) bool {
+		for _, t := range attributeTypes {
+			if s.IsSubtype(a, t) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var configured []*AttributeType
+	for attributeType, values := range data.Attributes.Elements() {
+		a := s.AttributeType(attributeType)
+		if a == nil {
+			diagnostics.AddAttributeError(
+				path.Root("attributes").AtMapKey(attributeType),
+				"Unknown attribute type",
+				fmt.Sprintf("The attribute type %s isn't defined in the schema of the server", attributeType),
+			)
+			continue
+		}
+		configured = append(configured, a)
+		if !allowAll && a.Usage == "userApplications" && !isOneOf(a, must) && !isOneOf(a, may) {
+			diagnostics.AddAttributeError(
+				path.Root("attributes").AtMapKey(attributeType),
+				"Attribute not allowed",
+				fmt.Sprintf(
+					"The attribute type %s isn't allowed by the object classes %s",
+					attributeType,
+					strings.Join(classNames, ", "),
+				),
+			)
+		}
+		if list, ok := values.(types.List); ok && a.SingleValue && len(list.Elements()) > 1 {
+			diagnostics.AddAttributeError(
+				path.Root("attributes").AtMapKey(attributeType),
+				"Multiple values for single-value attribute",
+				fmt.Sprintf("The attribute type %s is SINGLE-VALUE, but %d values are configured", attributeType, len(list.Elements())),
+			)
+		}
+	}
+	for attributeType := range data.InitialAttrs.Elements() {
+		if a := s.AttributeType(attributeType); a != nil {
+			configured = append(configured, a)
+		}
+	}
+	// the server adds the values of the RDN to the entry
+	if !data.DN.IsUnknown() {
+		if dn, err := ldap.ParseDN(data.DN.ValueString()); err == nil && len(dn.RDNs) > 0 {
+			for _, attribute := range dn.RDNs[0].Attributes {
+				if a := s.AttributeType(attribute.Type); a != nil {
+					configured = append(configured, a)
+				}
+			}
+		}
+	}
+
+	if data.ManagedOnly.ValueBool() {
+		// the entry may contain required attributes that aren't managed by the resource
+		return
+	}
+	reported := map[*AttributeType]bool{}
+	for _, a := range must {
+		if reported[a] || a.NoUserModification || funk.Contains(a.Names, "objectClass") ||
+			L.isIgnored(ctx, a.Name(), data, *diagnostics) {
+			continue
+		}
+		found := false
+		for _, c := range configured {
+			if s.IsSubtype(c, a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			reported[a] = true
+			diagnostics.AddAttributeError(
+				path.Root("attributes"),
+				"Missing required attribute",
+				fmt.Sprintf("The object classes %s require the attribute %s", strings.Join(classNames, ", "), a.Name()),
+			)
+		}
+	}
+}
+
 func (L *LDAPObjectResource) addLdapEntry(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) error {
 	var objectClasses []string
 	diagnostics.Append(data.ObjectClasses.ElementsAs(ctx, &objectClasses, false)...)
@@ -341,25 +704,544 @@ func (L *LDAPObjectResource) addLdapEntry(ctx context.Context, data *LDAPObjectR
 		return errors.New("error converting data")
 	}
 
+	var initialAttributes map[string][]string
+	diagnostics.Append(data.InitialAttrs.ElementsAs(ctx, &initialAttributes, false)...)
+	if diagnostics.HasError() {
+		return errors.New("error converting data")
+	}
+
+	ctx = MaskAttributes(ctx, attributes)
+	ctx = MaskAllAttributes(ctx, initialAttributes)
+
 	tflog.Info(ctx, "Adding new item", map[string]interface{}{
-		"dn":          data.DN.ValueString(),
-		"objectClass": objectClasses,
-		"attributes":  attributes,
+		"dn":                data.DN.ValueString(),
+		"objectClass":       objectClasses,
+		"attributes":        attributes,
+		"initialAttributes": funk.Keys(initialAttributes),
 	})
 	a := ldap.NewAddRequest(data.DN.ValueString(), []ldap.Control{})
 	a.Attribute("objectClass", objectClasses)
 
-	ctx = MaskAttributes(ctx, attributes)
-
 	for attributeType, values := range attributes {
 		a.Attribute(attributeType, values)
 	}
 
-	tflog.Debug(ctx, "Adding LDAP entry", map[string]interface{}{
-		"entry": ToLDIF(a),
+	for attributeType, values := range initialAttributes {
+		if _, exists := attributes[attributeType]; exists {
+			tflog.Warn(ctx, "Initial attribute is overridden by attributes", map[string]interface{}{
+				"type": attributeType,
+			})
+			continue
+		}
+		a.Attribute(attributeType, values)
+	}
+
+	var createdParents []string
+	var err error
+	if data.CreateParents.ValueBool() {
+		createdParents, err = L.createParents(ctx, data, diagnostics)
+	}
+	createdParentsValue, d := types.ListValueFrom(ctx, types.StringType, createdParents)
+	diagnostics.Append(d...)
+	data.CreatedParent = createdParentsValue
+
+	if err == nil {
+		tflog.Debug(ctx, "Adding LDAP entry", map[string]interface{}{
+			"entry": ToLDIF(a),
+		})
+		err = L.conn.Add(a)
+	}
+	if err != nil {
+		L.deleteEmptyParents(ctx, data, diagnostics)
+		return err
+	}
+	return nil
+}
+
+// moveRefusedResultCodes are returned by servers which don't support moving the entry, e.g. to another parent.
+var moveRefusedResultCodes = []uint16{
+	ldap.LDAPResultUnwillingToPerform,
+	ldap.LDAPResultAffectsMultipleDSAs,
+	ldap.LDAPResultProtocolError,
+}
+
+// isMoveRefused returns whether the server refused to move the entry, so it has to be recreated instead.
+func isMoveRefused(err error) bool {
+	for _, code := range moveRefusedResultCodes {
+		if ldap.IsErrorWithCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// moveLdapEntry renames the entry to the DN of the plan, moving it below its new parent if that changed. The entry
+// keeps its UUID, operational attributes and children.
+func (L *LDAPObjectResource) moveLdapEntry(ctx context.Context, stateData *LDAPObjectResourceModel, planData *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) error {
+	oldDN, err := ldap.ParseDN(stateData.DN.ValueString())
+	if err != nil {
+		return err
+	}
+	newDN, err := ldap.ParseDN(planData.DN.ValueString())
+	if err != nil {
+		return err
+	}
+	if len(oldDN.RDNs) == 0 || len(newDN.RDNs) == 0 {
+		return fmt.Errorf("can not move %s to %s", stateData.DN.ValueString(), planData.DN.ValueString())
+	}
+
+	oldParent := &ldap.DN{RDNs: oldDN.RDNs[1:]}
+	newParent := &ldap.DN{RDNs: newDN.RDNs[1:]}
+	var newSuperior string
+	if !oldParent.EqualFold(newParent) {
+		newSuperior = newParent.String()
+
+		var createdParents []string
+		if planData.CreateParents.ValueBool() {
+			if createdParents, err = L.createParents(ctx, planData, diagnostics); err != nil {
+				return err
+			}
+		}
+		createdParentsValue, d := types.ListValueFrom(ctx, types.StringType, createdParents)
+		diagnostics.Append(d...)
+		planData.CreatedParent = createdParentsValue
+	}
+
+	r := ldap.NewModifyDNRequest(stateData.DN.ValueString(), newDN.RDNs[0].String(), true, newSuperior)
+	tflog.Debug(ctx, "Modifying DN", map[string]interface{}{
+		"dn":          r.DN,
+		"newRdn":      r.NewRDN,
+		"newSuperior": r.NewSuperior,
 	})
+	if err := L.conn.ModifyDN(r); err != nil {
+		if newSuperior != "" {
+			L.deleteEmptyParents(ctx, planData, diagnostics)
+		}
+		return err
+	}
+	if newSuperior != "" {
+		L.deleteEmptyParents(ctx, stateData, diagnostics)
+	}
+	return nil
+}
+
+// createParents creates the missing parent entries of the DN and returns their DNs from the top down.
+func (L *LDAPObjectResource) createParents(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) ([]string, error) {
+	var parentObjectClasses map[string][]string
+	diagnostics.Append(data.ParentClasses.ElementsAs(ctx, &parentObjectClasses, false)...)
+	if diagnostics.HasError() {
+		return nil, errors.New("error converting data")
+	}
+
+	dn, err := ldap.ParseDN(data.DN.ValueString())
+	if err != nil {
+		return nil, err
+	}
 
-	return L.conn.Add(a)
+	var missingParents []*ldap.DN
+	for i := 1; i < len(dn.RDNs); i++ {
+		parent := &ldap.DN{RDNs: dn.RDNs[i:]}
+		if _, err := GetEntry(L.conn, parent.String(), "1.1"); err == nil {
+			break
+		} else if !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, err
+		}
+		missingParents = append(missingParents, parent)
+	}
+
+	var createdParents []string
+	for i := len(missingParents) - 1; i >= 0; i-- {
+		parent := missingParents[i]
+		objectClasses := defaultParentObjectClasses
+		for attributeType, classes := range parentObjectClasses {
+			if strings.EqualFold(attributeType, parent.RDNs[0].Attributes[0].Type) {
+				objectClasses = classes
+			}
+		}
+
+		a := ldap.NewAddRequest(parent.String(), []ldap.Control{})
+		a.Attribute("objectClass", objectClasses)
+		for _, attribute := range parent.RDNs[0].Attributes {
+			a.Attribute(attribute.Type, []string{attribute.Value})
+		}
+
+		tflog.Info(ctx, "Adding missing parent entry", map[string]interface{}{
+			"entry": ToLDIF(a),
+		})
+		if err := L.conn.Add(a); err != nil {
+			return createdParents, err
+		}
+		createdParents = append(createdParents, parent.String())
+	}
+	return createdParents, nil
+}
+
+// backupEntries writes the entry (or all entries in the given scope) with the user and the fetched operational
+// attributes as LDIF into the backup directory, if one is configured.
+func (L *LDAPObjectResource) backupEntries(ctx context.Context, data *LDAPObjectResourceModel, scope int, diagnostics *diag.Diagnostics) error {
+	if L.backupDir == "" {
+		return nil
+	}
+
+	attributeTypes := append(L.operationalAttributeTypes(ctx, data, diagnostics), "*")
+	s := ldap.NewSearchRequest(data.DN.ValueString(), scope, ldap.NeverDerefAliases, 0, 0, false, "(objectClass=*)", attributeTypes, []ldap.Control{})
+	result, err := L.conn.Search(s)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	backup := ToLDIF(result.Entries)
+	if backup == "" {
+		return errors.New("can not convert entries to LDIF")
+	}
+
+	if err := os.MkdirAll(L.backupDir, 0700); err != nil {
+		return err
+	}
+	filename := filepath.Join(
+		L.backupDir,
+		fmt.Sprintf("%s-%s.ldif", time.Now().UTC().Format("20060102T150405.000000Z"), backupFilenameReplacer.ReplaceAllString(data.DN.ValueString(), "_")),
+	)
+	if err := os.WriteFile(filename, []byte(backup), 0600); err != nil {
+		return err
+	}
+	tflog.Info(ctx, "Backed up entries", map[string]interface{}{
+		"dn":      data.DN.ValueString(),
+		"entries": len(result.Entries),
+		"file":    filename,
+	})
+	return nil
+}
+
+// deleteManagedAttributes removes the attribute values set by the resource from the entry but keeps the entry itself.
+func (L *LDAPObjectResource) deleteManagedAttributes(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) error {
+	entry, err := GetEntry(L.conn, data.DN.ValueString())
+	if err != nil {
+		return err
+	}
+
+	var attributes map[string][]string
+	diagnostics.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+	if diagnostics.HasError() {
+		return errors.New("error converting data")
+	}
+
+	dn, err := ldap.ParseDN(data.DN.ValueString())
+	if err != nil {
+		return err
+	}
+	isRDNValue := func(attributeType string, value string) bool {
+		if len(dn.RDNs) == 0 {
+			return false
+		}
+		for _, attribute := range dn.RDNs[0].Attributes {
+			if strings.EqualFold(attribute.Type, attributeType) && strings.EqualFold(attribute.Value, value) {
+				return true
+			}
+		}
+		return false
+	}
+	isRequired := L.requiredAttributeTypes(ctx, entry)
+
+	ctx = MaskAttributes(ctx, attributes)
+	r := ldap.NewModifyRequest(data.DN.ValueString(), []ldap.Control{})
+	for attributeType, values := range attributes {
+		if L.isIgnored(ctx, attributeType, data, *diagnostics) {
+			continue
+		}
+		existingValues := entry.GetEqualFoldAttributeValues(attributeType)
+		var valuesToDelete []string
+		for _, value := range values {
+			if isRDNValue(attributeType, value) {
+				tflog.Info(ctx, "Keeping attribute value of the RDN", map[string]interface{}{"type": attributeType})
+			} else if funk.ContainsString(existingValues, value) {
+				valuesToDelete = append(valuesToDelete, value)
+			}
+		}
+		if len(valuesToDelete) > 0 && len(valuesToDelete) == len(existingValues) && isRequired(attributeType) {
+			tflog.Info(ctx, "Keeping attribute required by the object classes of the entry", map[string]interface{}{
+				"type": attributeType,
+			})
+			continue
+		}
+		if len(valuesToDelete) > 0 {
+			tflog.Debug(ctx, "Removing attribute values", map[string]interface{}{
+				"type":   attributeType,
+				"values": valuesToDelete,
+			})
+			r.Delete(attributeType, valuesToDelete)
+		}
+	}
+	if len(r.Changes) == 0 {
+		return nil
+	}
+	return L.conn.Modify(r)
+}
+
+// subtreeSearchPageSize is the page size used to fetch the entries of a subtree, so that the administrative size limit of
+// the server doesn't apply to the whole subtree.
+const subtreeSearchPageSize = 500
+
+// subtreeSizeError returns the error of the search for the entries of a subtree. It only blames subtree_delete_limit if
+// more entries than the limit were returned, and the size limit of the server otherwise.
+func subtreeSizeError(result *ldap.SearchResult, err error, limit int64) error {
+	var entries int64
+	if result != nil {
+		entries = int64(len(result.Entries))
+	}
+	if entries > limit {
+		return fmt.Errorf("the subtree contains more than %d entries allowed by subtree_delete_limit", limit)
+	} else if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return fmt.Errorf("the size limit of the server stopped the search for the entries of the subtree after %d entries, so they can't be deleted safely: %w", entries, err)
+	}
+	return err
+}
+
+// requiredAttributeTypes returns a function which checks whether the object classes of the entry require an attribute
+// type. Nothing is required if the schema of the server can't be read.
+func (L *LDAPObjectResource) requiredAttributeTypes(ctx context.Context, entry ldap.Entry) func(attributeType string) bool {
+	s, err := L.schema()
+	if err != nil {
+		tflog.Warn(ctx, "Can not read the schema of the server, assuming no attributes are required", map[string]interface{}{"error": err.Error()})
+		return func(string) bool { return false }
+	}
+	var classes []*ObjectClass
+	for _, name := range entry.GetEqualFoldAttributeValues("objectClass") {
+		if c := s.ObjectClass(name); c != nil {
+			classes = append(classes, c)
+		}
+	}
+	must, _ := s.ClassAttributeTypes(classes)
+	return func(attributeType string) bool {
+		a := s.AttributeType(attributeType)
+		if a == nil {
+			return false
+		}
+		for _, m := range must {
+			if s.IsSubtype(a, m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// deleteSubtree deletes the entry including all entries below it if they don't exceed the configured limit. It uses
+// the Tree Delete control if the server supports it and deletes the entries from the bottom up otherwise.
+func (L *LDAPObjectResource) deleteSubtree(ctx context.Context, data *LDAPObjectResourceModel) error {
+	limit := data.DeleteLimit.ValueInt64()
+	s := ldap.NewSearchRequest(data.DN.ValueString(), ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, int(limit)+1, 0, false, "(objectClass=*)", []string{"1.1"}, []ldap.Control{})
+	result, err := L.conn.SearchWithPaging(s, subtreeSearchPageSize)
+	if err := subtreeSizeError(result, err, limit); err != nil {
+		return err
+	}
+
+	if SupportsControl(L.conn, ldap.ControlTypeSubtreeDelete) {
+		tflog.Info(ctx, "Deleting subtree using the Tree Delete control", map[string]interface{}{
+			"dn":      data.DN.ValueString(),
+			"entries": len(result.Entries),
+		})
+		return L.conn.Del(ldap.NewDelRequest(data.DN.ValueString(), []ldap.Control{ldap.NewControlSubtreeDelete()}))
+	}
+
+	tflog.Info(ctx, "Deleting subtree entry by entry", map[string]interface{}{
+		"dn":      data.DN.ValueString(),
+		"entries": len(result.Entries),
+	})
+	entries := result.Entries
+	sort.SliceStable(entries, func(i, j int) bool {
+		return DNDepth(entries[i].DN) > DNDepth(entries[j].DN)
+	})
+	for _, entry := range entries {
+		tflog.Debug(ctx, "Deleting subtree entry", map[string]interface{}{"dn": entry.DN})
+		if err := L.conn.Del(ldap.NewDelRequest(entry.DN, []ldap.Control{})); err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteEmptyParents removes the parent entries created for the entry as long as they are empty.
+func (L *LDAPObjectResource) deleteEmptyParents(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) {
+	var createdParents []string
+	diagnostics.Append(data.CreatedParent.ElementsAs(ctx, &createdParents, false)...)
+
+	for i := len(createdParents) - 1; i >= 0; i-- {
+		if err := L.conn.Del(ldap.NewDelRequest(createdParents[i], []ldap.Control{})); err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNotAllowedOnNonLeaf) {
+				tflog.Info(ctx, "Keeping parent entry which isn't empty", map[string]interface{}{"dn": createdParents[i]})
+				return
+			} else if !ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				diagnostics.AddWarning(
+					"Can not delete parent entry",
+					fmt.Sprintf("Trying to delete created parent entry %s returned: %s", createdParents[i], err),
+				)
+				return
+			}
+		} else {
+			tflog.Info(ctx, "Deleted empty parent entry", map[string]interface{}{"dn": createdParents[i]})
+		}
+	}
+}
+
+// operationalAttributeTypes returns the operational attributes to fetch for the resource.
+func (L *LDAPObjectResource) operationalAttributeTypes(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) []string {
+	if data.FetchOpAttrs.IsNull() {
+		return defaultOperationalAttributes
+	}
+	var operationalAttributeTypes []string
+	diagnostics.Append(data.FetchOpAttrs.ElementsAs(ctx, &operationalAttributeTypes, false)...)
+	return operationalAttributeTypes
+}
+
+// readGeneratedAttributes fetches the operational attributes and the UUID of the entry into the model.
+func (L *LDAPObjectResource) readGeneratedAttributes(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) {
+	operationalAttributeTypes := L.operationalAttributeTypes(ctx, data, diagnostics)
+	operationalAttributes := make(map[string][]string)
+	data.EntryUUID = types.StringNull()
+	if entry, err := GetEntry(L.conn, data.DN.ValueString(), append(operationalAttributeTypes, UUIDAttributeTypes...)...); err != nil {
+		diagnostics.AddWarning(
+			"Can not read operational attributes",
+			err.Error(),
+		)
+	} else {
+		for _, attribute := range entry.Attributes {
+			if ContainsAttributeType(operationalAttributeTypes, attribute.Name) {
+				operationalAttributes[attribute.Name] = PrintableValues(attribute)
+			}
+		}
+		if uuid := EntryUUID(entry); uuid != "" {
+			data.EntryUUID = types.StringValue(uuid)
+		}
+	}
+	value, d := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, operationalAttributes)
+	diagnostics.Append(d...)
+	data.OpAttrs = value
+}
+
+// adoptLdapEntry takes over an existing entry by modifying it to match the plan.
+func (L *LDAPObjectResource) adoptLdapEntry(ctx context.Context, data *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) error {
+	tflog.Info(ctx, "Adopting existing entry", map[string]interface{}{"dn": data.DN.ValueString()})
+	entry, err := GetEntry(L.conn, data.DN.ValueString())
+	if err != nil {
+		return err
+	}
+
+	ctx = MaskAttributesFromArray(ctx, entry.Attributes)
+	var objectClasses []string
+	attributes := make(map[string][]string)
+	for _, attribute := range entry.Attributes {
+		if attribute.Name == "objectClass" {
+			objectClasses = attribute.Values
+		} else if !L.isIgnored(ctx, attribute.Name, data, *diagnostics) && L.isManaged(ctx, attribute.Name, data, *diagnostics) {
+			attributes[attribute.Name] = attribute.Values
+		}
+	}
+	tflog.Debug(ctx, "Existing entry", map[string]interface{}{"entry": ToLDIF(&entry)})
+
+	existingData := *data
+	var d diag.Diagnostics
+	existingData.ObjectClasses, d = types.ListValueFrom(ctx, types.StringType, objectClasses)
+	diagnostics.Append(d...)
+	existingData.Attributes, d = types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, attributes)
+	diagnostics.Append(d...)
+	if diagnostics.HasError() {
+		return errors.New("error converting data")
+	}
+
+	return L.modifyLdapEntry(ctx, &existingData, data, diagnostics)
+}
+
+// modifyLdapEntry modifies the entry so that its object classes and attributes in the state match the plan.
+func (L *LDAPObjectResource) modifyLdapEntry(ctx context.Context, stateData *LDAPObjectResourceModel, planData *LDAPObjectResourceModel, diagnostics *diag.Diagnostics) error {
+	r := ldap.NewModifyRequest(planData.DN.ValueString(), []ldap.Control{})
+
+	var stateObjectClasses []string
+	diagnostics.Append(stateData.ObjectClasses.ElementsAs(ctx, &stateObjectClasses, false)...)
+	var planObjectClasses []string
+	diagnostics.Append(planData.ObjectClasses.ElementsAs(ctx, &planObjectClasses, false)...)
+
+	var classesToAdd []string
+	for _, class := range planObjectClasses {
+		if funk.IndexOf(stateObjectClasses, class) == -1 {
+			classesToAdd = append(classesToAdd, class)
+		}
+	}
+
+	if len(classesToAdd) > 0 {
+		r.Add("objectClass", classesToAdd)
+	}
+
+	var stateAttributes map[string][]string
+	diagnostics.Append(stateData.Attributes.ElementsAs(ctx, &stateAttributes, false)...)
+	var planAttributes map[string][]string
+	diagnostics.Append(planData.Attributes.ElementsAs(ctx, &planAttributes, false)...)
+
+	ctx = MaskAttributes(ctx, stateAttributes)
+	for attributeType, stateValues := range stateAttributes {
+		if L.isIgnored(ctx, attributeType, stateData, *diagnostics) {
+			continue
+		}
+		// state attribute is in the plan, compare the values
+		if planValues, exists := planAttributes[attributeType]; exists {
+			valuesChanged := false
+			for _, stateValue := range stateValues {
+				if !funk.ContainsString(planValues, stateValue) {
+					valuesChanged = true
+				}
+			}
+			for _, planValue := range planValues {
+				if !funk.ContainsString(stateValues, planValue) {
+					valuesChanged = true
+				}
+			}
+			if valuesChanged {
+				tflog.Debug(ctx, "Changing attribute", map[string]interface{}{
+					"type":   attributeType,
+					"values": planValues,
+				})
+				r.Replace(attributeType, planValues)
+			}
+		} else if planData.ManagedOnly.ValueBool() {
+			tflog.Debug(ctx, "Attribute is not managed anymore", map[string]interface{}{
+				"type": attributeType,
+			})
+		} else {
+			tflog.Debug(ctx, "Removing attribute", map[string]interface{}{
+				"type": attributeType,
+			})
+			r.Delete(attributeType, []string{})
+		}
+	}
+	for attributeType, values := range planAttributes {
+		if L.isIgnored(ctx, attributeType, planData, *diagnostics) {
+			continue
+		}
+		if _, exists := stateAttributes[attributeType]; !exists {
+			if planData.ManagedOnly.ValueBool() {
+				// the attribute may already exist on a shared entry without being managed yet
+				tflog.Debug(ctx, "Setting newly managed attribute", map[string]interface{}{
+					"type": attributeType,
+				})
+				r.Replace(attributeType, values)
+				continue
+			}
+			tflog.Debug(ctx, "Adding attribute", map[string]interface{}{
+				"type": attributeType,
+			})
+			r.Add(attributeType, values)
+		}
+	}
+	if diagnostics.HasError() {
+		return errors.New("error converting data")
+	}
+	if len(r.Changes) == 0 {
+		tflog.Debug(ctx, "Entry is unchanged", map[string]interface{}{"dn": planData.DN.ValueString()})
+		return nil
+	}
+	return L.conn.Modify(r)
 }
 
 func (L *LDAPObjectResource) isIgnored(ctx context.Context, attributeType string, data *LDAPObjectResourceModel, diagnostics diag.Diagnostics) bool {
@@ -369,5 +1251,37 @@ func (L *LDAPObjectResource) isIgnored(ctx context.Context, attributeType string
 	if diagnostics.HasError() {
 		return false
 	}
-	return funk.ContainsString(ignoredAttributes, attributeType)
+	// initial attributes are only set on creation and not managed afterwards unless they're part of the attributes
+	if _, exists := data.InitialAttrs.Elements()[attributeType]; exists {
+		if _, managed := data.Attributes.Elements()[attributeType]; !managed {
+			return true
+		}
+	}
+	for _, pattern := range append(ignoredAttributes, L.defaultIgnoreChanges...) {
+		if matches, err := MatchAttributeType(pattern, attributeType); err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
+
+// isManaged checks whether the given attribute type is managed by the resource. This is always the case unless
+// managed_attributes_only is set, which limits the managed types to the ones set in the attributes.
+func (L *LDAPObjectResource) isManaged(ctx context.Context, attributeType string, data *LDAPObjectResourceModel, diagnostics diag.Diagnostics) bool {
+	if !data.ManagedOnly.ValueBool() {
+		return true
+	}
+
+	var attributes map[string][]string
+	diagnostics.Append(data.Attributes.ElementsAs(ctx, &attributes, false)...)
+
+	if diagnostics.HasError() {
+		return false
+	}
+	for managedType := range attributes {
+		if strings.EqualFold(managedType, attributeType) {
+			return true
+		}
+	}
+	return false
 }
@@ -7,7 +7,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 )
 
@@ -24,6 +27,9 @@ func TestLDAPObjectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("ldap_object.test", "object_classes.0", "person"),
 					resource.TestCheckResourceAttr("ldap_object.test", "attributes.sn.0", "test"),
 					resource.TestCheckResourceAttr("ldap_object.test", "attributes.userPassword.0", "password"),
+					resource.TestCheckResourceAttrSet("ldap_object.test", "operational_attributes.entryUUID.0"),
+					resource.TestCheckResourceAttr("ldap_object.test", "operational_attributes.creatorsName.0", "cn=admin,dc=example,dc=com"),
+					resource.TestCheckNoResourceAttr("ldap_object.test", "attributes.entryUUID.0"),
 				),
 			},
 			// Update test
@@ -71,6 +77,376 @@ func TestLDAPObjectResource(t *testing.T) {
 	)
 }
 
+func TestLDAPObjectResourceDeletedExternally(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDeletedExternallyConfig,
+			},
+			// Entry was deleted outside of Terraform and is recreated
+			{
+				Config:    testDeletedExternallyConfig,
+				PreConfig: testDeleteExternally,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.deletedexternally", "dn", "cn=deletedexternally,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.deletedexternally", "attributes.sn.0", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceManagedAttributesOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testManagedAttributesOnlyConfig,
+			},
+			// Attributes added outside of Terraform are neither read nor removed
+			{
+				Config:    testManagedAttributesOnlyConfig,
+				PreConfig: testAddDescriptionExternally,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.managedonly", "attributes.sn.0", "test"),
+					resource.TestCheckNoResourceAttr("ldap_object.managedonly", "attributes.description.0"),
+				),
+			},
+			// Managing an attribute which already exists replaces its values
+			{
+				Config: testManagedAttributesOnlyDescriptionConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.managedonly", "attributes.description.#", "1"),
+					resource.TestCheckResourceAttr("ldap_object.managedonly", "attributes.description.0", "managed"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceIgnorePatterns(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testIgnorePatternsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.ignorepatterns", "attributes.description.0", "test"),
+				),
+			},
+			// Changes to attributes matching an ignore pattern are ignored
+			{
+				Config: testIgnorePatternsUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.ignorepatterns", "attributes.description.0", "test"),
+					resource.TestCheckResourceAttr("ldap_object.ignorepatterns", "attributes.telephoneNumber.0", "123"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceDefaultIgnoreChanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testDefaultIgnoreChangesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.defaultignore", "attributes.description.0", "test"),
+				),
+			},
+			// Changes to attributes ignored by the provider are ignored
+			{
+				Config: testDefaultIgnoreChangesUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.defaultignore", "attributes.description.0", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceInitialAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testInitialAttributesConfig("initial"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.initialattributes", "attributes.sn.0", "test"),
+					resource.TestCheckNoResourceAttr("ldap_object.initialattributes", "attributes.userPassword.0"),
+				),
+			},
+			// Changing initial attributes doesn't plan an update
+			{
+				Config:   testInitialAttributesConfig("changed"),
+				PlanOnly: true,
+			},
+			// Initial attributes aren't managed after the creation
+			{
+				Config: testInitialAttributesConfig("changed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("ldap_object.initialattributes", "attributes.userPassword.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceMovedExternally(t *testing.T) {
+	var entryUUID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testMovedExternallyConfig("movedexternally"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("ldap_object.movedexternally", "entry_uuid", func(value string) error {
+						entryUUID = value
+						return nil
+					}),
+				),
+			},
+			// Entry was renamed outside of Terraform and is found by its UUID
+			{
+				Config:    testMovedExternallyConfig("movedexternally2"),
+				PreConfig: testRenameExternally("movedexternally", "movedexternally2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.movedexternally", "dn", "cn=movedexternally2,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.movedexternally", "id", "cn=movedexternally2,dc=example,dc=com"),
+				),
+			},
+			// Entry is renamed back to the configured DN instead of being recreated
+			{
+				Config:    testMovedExternallyConfig("movedexternally2"),
+				PreConfig: testRenameExternally("movedexternally2", "movedexternally3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.movedexternally", "dn", "cn=movedexternally2,dc=example,dc=com"),
+					resource.TestCheckResourceAttrWith("ldap_object.movedexternally", "entry_uuid", func(value string) error {
+						if value != entryUUID {
+							return fmt.Errorf("entry was recreated with UUID %s instead of %s", value, entryUUID)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceAdopt(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:    testAdoptConfig,
+				PreConfig: testAdoptPreConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.adopttest", "id", "cn=adopttest,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.adopttest", "object_classes.#", "2"),
+					resource.TestCheckResourceAttr("ldap_object.adopttest", "attributes.sn.0", "adopted"),
+					resource.TestCheckNoResourceAttr("ldap_object.adopttest", "attributes.description.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceCreateParents(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCreateParentsCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testCreateParentsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.createparents", "created_parents.#", "2"),
+					resource.TestCheckResourceAttr("ldap_object.createparents", "created_parents.0", "ou=people,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.createparents", "created_parents.1", "ou=eng,ou=people,dc=example,dc=com"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceSubtreeDelete(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testSubtreeDeleteCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testSubtreeDeleteMissingLimitConfig,
+				ExpectError: regexp.MustCompile("Missing subtree delete limit"),
+			},
+			{
+				Config: testSubtreeDeleteConfig,
+			},
+			// Add an unmanaged child which is deleted on destroy
+			{
+				Config:    testSubtreeDeleteConfig,
+				PreConfig: testSubtreeDeletePreConfig,
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceDeleteManagedAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testDeleteManagedAttributesCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:    testDeleteManagedAttributesConfig,
+				PreConfig: testDeleteManagedAttributesPreConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.deletemanaged", "attributes.description.0", "managed"),
+				),
+			},
+			{
+				Config: testDeleteManagedAttributesConfig + testDeleteManagedRequiredAttributesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.deletemanagedrequired", "attributes.cn.0", "deletemanagedrequired"),
+					resource.TestCheckResourceAttr("ldap_object.deletemanagedrequired", "attributes.sn.0", "test"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceBackup(t *testing.T) {
+	backupDir := t.TempDir()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(_ *terraform.State) error {
+			if files, err := filepath.Glob(filepath.Join(backupDir, "*-cn=backuptest,dc=example,dc=com.ldif")); err != nil {
+				return err
+			} else if len(files) != 1 {
+				return fmt.Errorf("expected one backup file, found %d", len(files))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testBackupConfig(backupDir),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceSchemaValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The attribute of the RDN doesn't have to be configured
+			{
+				Config:             testSchemaValidationConfig("person", `"sn" = ["test"]`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testSchemaValidationConfig("person", `"cn" = ["schema"], "sn" = ["test"], "surename" = ["test"]`),
+				ExpectError: regexp.MustCompile("Unknown attribute type"),
+			},
+			{
+				Config:      testSchemaValidationConfig("person", `"cn" = ["schema"], "sn" = ["test"], "mail" = ["schema@example.com"]`),
+				ExpectError: regexp.MustCompile("Attribute not allowed"),
+			},
+			{
+				Config:      testSchemaValidationConfig("person", `"cn" = ["schema"]`),
+				ExpectError: regexp.MustCompile("require the attribute sn"),
+			},
+			{
+				Config:      testSchemaValidationConfig("inetOrgPerson", `"cn" = ["schema"], "sn" = ["test"], "displayName" = ["a", "b"]`),
+				ExpectError: regexp.MustCompile("Multiple values for single-value attribute"),
+			},
+			{
+				Config: testSchemaValidationConfig("inetOrgPerson", `"cn" = ["schema"], "sn" = ["test"], "displayName" = ["a"]`),
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceDNOutsideBaseDN(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A DN outside of the base DN is used as is
+			{
+				Config: testDNOutsideBaseDNConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.outsidebasedn", "dn", "cn=outsidebasedn,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.outsidebasedn", "id", "cn=outsidebasedn,dc=example,dc=com"),
+				),
+			},
+			{
+				Config:   testDNOutsideBaseDNConfig,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestLDAPObjectResourceRDN(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testRDNConfig(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.rdntest", "dn", "cn=Smith\\, John,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("ldap_object.rdntest", "id", "cn=Smith\\, John,dc=example,dc=com"),
+				),
+			},
+			{
+				Config: testRDNConfig(`parent_dn = "ou=people,dc=example,dc=com"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ldap_object.rdntest", "dn", "cn=Smith\\, John,ou=people,dc=example,dc=com"),
+				),
+			},
+			{
+				Config:   testRDNConfig(`parent_dn = "ou=people,dc=example,dc=com"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestSubtreeSizeError(t *testing.T) {
+	entries := func(n int) *ldap.SearchResult {
+		result := &ldap.SearchResult{}
+		for i := 0; i < n; i++ {
+			result.Entries = append(result.Entries, &ldap.Entry{})
+		}
+		return result
+	}
+	sizeLimitExceeded := ldap.NewError(ldap.LDAPResultSizeLimitExceeded, fmt.Errorf("size limit exceeded"))
+
+	assert.NoError(t, subtreeSizeError(entries(5), nil, 5))
+	assert.ErrorContains(t, subtreeSizeError(entries(6), nil, 5), "subtree_delete_limit")
+	assert.ErrorContains(t, subtreeSizeError(entries(6), sizeLimitExceeded, 5), "subtree_delete_limit")
+	if err := subtreeSizeError(entries(500), sizeLimitExceeded, 5000); assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "subtree_delete_limit")
+		assert.Contains(t, err.Error(), "size limit of the server")
+	}
+	assert.Error(t, subtreeSizeError(nil, fmt.Errorf("connection closed"), 5))
+}
+
 func TestImport(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -124,6 +500,423 @@ func testChangePasswordExternally() {
 	}
 }
 
+func testDeleteExternally() {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return
+		}
+		if err := conn.Del(ldap.NewDelRequest("cn=deletedexternally,dc=example,dc=com", []ldap.Control{})); err != nil {
+			return
+		}
+	}
+}
+
+const testDeletedExternallyConfig = `
+resource "ldap_object" "deletedexternally" {
+	dn = "cn=deletedexternally,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["deletedexternally"]
+		"sn" = ["test"]
+	}
+}
+`
+
+func testAddDescriptionExternally() {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return
+		}
+		r := ldap.NewModifyRequest("cn=managedonly,dc=example,dc=com", []ldap.Control{})
+		r.Add("description", []string{"external"})
+		if err := conn.Modify(r); err != nil {
+			return
+		}
+	}
+}
+
+const testManagedAttributesOnlyConfig = `
+resource "ldap_object" "managedonly" {
+	dn = "cn=managedonly,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["managedonly"]
+		"sn" = ["test"]
+	}
+	managed_attributes_only = true
+}
+`
+
+const testManagedAttributesOnlyDescriptionConfig = `
+resource "ldap_object" "managedonly" {
+	dn = "cn=managedonly,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["managedonly"]
+		"sn" = ["test"]
+		"description" = ["managed"]
+	}
+	managed_attributes_only = true
+}
+`
+
+const testIgnorePatternsConfig = `
+resource "ldap_object" "ignorepatterns" {
+	dn = "cn=ignorepatterns,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["ignorepatterns"]
+		"sn" = ["test"]
+		"description" = ["test"]
+		"telephoneNumber" = ["123"]
+	}
+	ignore_changes = ["desc*", "/telephone.*/"]
+}
+`
+
+const testIgnorePatternsUpdateConfig = `
+resource "ldap_object" "ignorepatterns" {
+	dn = "cn=ignorepatterns,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["ignorepatterns"]
+		"sn" = ["test"]
+		"description" = ["changed"]
+		"telephoneNumber" = ["456"]
+	}
+	ignore_changes = ["desc*", "/telephone.*/"]
+}
+`
+
+const testDefaultIgnoreChangesConfig = `
+provider "ldap" {
+	default_ignore_changes = ["description"]
+}
+
+resource "ldap_object" "defaultignore" {
+	dn = "cn=defaultignore,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["defaultignore"]
+		"sn" = ["test"]
+		"description" = ["test"]
+	}
+}
+`
+
+const testDefaultIgnoreChangesUpdateConfig = `
+provider "ldap" {
+	default_ignore_changes = ["description"]
+}
+
+resource "ldap_object" "defaultignore" {
+	dn = "cn=defaultignore,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["defaultignore"]
+		"sn" = ["test"]
+		"description" = ["changed"]
+	}
+}
+`
+
+func testInitialAttributesConfig(password string) string {
+	return fmt.Sprintf(`
+resource "ldap_object" "initialattributes" {
+	dn = "cn=initialattributes,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["initialattributes"]
+		"sn" = ["test"]
+	}
+	initial_attributes = {
+		"userPassword" = ["%s"]
+	}
+}
+`, password)
+}
+
+func testRenameExternally(from string, to string) func() {
+	return func() {
+		ldapUrl := os.Getenv("LDAP_URL")
+		ldapBindDN := os.Getenv("LDAP_BIND_DN")
+		ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+		if conn, err := ldap.DialURL(ldapUrl); err != nil {
+			return
+		} else {
+			if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+				return
+			}
+			r := ldap.NewModifyDNRequest(fmt.Sprintf("cn=%s,dc=example,dc=com", from), fmt.Sprintf("cn=%s", to), true, "")
+			if err := conn.ModifyDN(r); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func testMovedExternallyConfig(cn string) string {
+	return fmt.Sprintf(`
+resource "ldap_object" "movedexternally" {
+	dn = "cn=%s,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["%s"]
+		"sn" = ["test"]
+	}
+}
+`, cn, cn)
+}
+
+const testCreateParentsConfig = `
+resource "ldap_object" "createparents" {
+	dn = "uid=alice,ou=eng,ou=people,dc=example,dc=com"
+	object_classes = ["person", "uidObject"]
+	attributes = {
+		"cn" = ["alice"]
+		"sn" = ["test"]
+		"uid" = ["alice"]
+	}
+	create_parents = true
+}
+`
+
+func testCreateParentsCheckDestroy(_ *terraform.State) error {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return err
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return err
+		}
+		if _, err := GetEntry(conn, "ou=people,dc=example,dc=com"); err == nil {
+			return fmt.Errorf("created parent entry wasn't deleted")
+		}
+	}
+	return nil
+}
+
+const testSubtreeDeleteMissingLimitConfig = `
+resource "ldap_object" "subtreedelete" {
+	dn = "ou=subtreedelete,dc=example,dc=com"
+	object_classes = ["organizationalUnit"]
+	attributes = {
+		"ou" = ["subtreedelete"]
+	}
+	delete_mode = "subtree"
+}
+`
+
+const testSubtreeDeleteConfig = `
+resource "ldap_object" "subtreedelete" {
+	dn = "ou=subtreedelete,dc=example,dc=com"
+	object_classes = ["organizationalUnit"]
+	attributes = {
+		"ou" = ["subtreedelete"]
+	}
+	delete_mode = "subtree"
+	subtree_delete_limit = 5
+}
+`
+
+func testSubtreeDeletePreConfig() {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return
+		}
+		r := ldap.NewAddRequest("cn=child,ou=subtreedelete,dc=example,dc=com", []ldap.Control{})
+		r.Attribute("objectClass", []string{"person"})
+		r.Attribute("sn", []string{"test"})
+		if err := conn.Add(r); err != nil {
+			return
+		}
+	}
+}
+
+func testSubtreeDeleteCheckDestroy(_ *terraform.State) error {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return err
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return err
+		}
+		if _, err := GetEntry(conn, "ou=subtreedelete,dc=example,dc=com"); err == nil {
+			return fmt.Errorf("subtree wasn't deleted")
+		}
+	}
+	return nil
+}
+
+const testDeleteManagedAttributesConfig = `
+resource "ldap_object" "deletemanaged" {
+	dn = "cn=deletemanaged,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"description" = ["managed"]
+	}
+	on_conflict = "adopt"
+	managed_attributes_only = true
+	delete_mode = "managed_attributes"
+}
+`
+
+const testDeleteManagedRequiredAttributesConfig = `
+resource "ldap_object" "deletemanagedrequired" {
+	dn = "cn=deletemanagedrequired,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["deletemanagedrequired"]
+		"sn" = ["test"]
+		"description" = ["managed"]
+	}
+	delete_mode = "managed_attributes"
+}
+`
+
+func testDeleteManagedAttributesPreConfig() {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return
+		}
+		r := ldap.NewAddRequest("cn=deletemanaged,dc=example,dc=com", []ldap.Control{})
+		r.Attribute("objectClass", []string{"person"})
+		r.Attribute("sn", []string{"test"})
+		if err := conn.Add(r); err != nil {
+			return
+		}
+	}
+}
+
+func testDeleteManagedAttributesCheckDestroy(_ *terraform.State) error {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return err
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return err
+		}
+		if entry, err := GetEntry(conn, "cn=deletemanaged,dc=example,dc=com"); err != nil {
+			return fmt.Errorf("entry was deleted: %s", err)
+		} else if len(entry.GetAttributeValues("description")) > 0 {
+			return fmt.Errorf("managed attribute wasn't removed")
+		} else if entry.GetAttributeValue("sn") != "test" {
+			return fmt.Errorf("unmanaged attribute was removed")
+		}
+		if err := conn.Del(ldap.NewDelRequest("cn=deletemanaged,dc=example,dc=com", []ldap.Control{})); err != nil {
+			return err
+		}
+
+		// the values of the RDN and the required attributes are kept
+		if entry, err := GetEntry(conn, "cn=deletemanagedrequired,dc=example,dc=com"); err != nil {
+			return fmt.Errorf("entry with required attributes was deleted: %s", err)
+		} else if len(entry.GetAttributeValues("description")) > 0 {
+			return fmt.Errorf("managed attribute of the entry with required attributes wasn't removed")
+		} else if entry.GetAttributeValue("cn") != "deletemanagedrequired" || entry.GetAttributeValue("sn") != "test" {
+			return fmt.Errorf("value of the RDN or required attribute was removed")
+		}
+		if err := conn.Del(ldap.NewDelRequest("cn=deletemanagedrequired,dc=example,dc=com", []ldap.Control{})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testBackupConfig(backupDir string) string {
+	return fmt.Sprintf(`
+provider "ldap" {
+	backup_dir = "%s"
+}
+
+resource "ldap_object" "backuptest" {
+	dn = "cn=backuptest,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["backuptest"]
+		"sn" = ["test"]
+	}
+}
+`, backupDir)
+}
+
+func testSchemaValidationConfig(objectClass string, attributes string) string {
+	return fmt.Sprintf(`
+provider "ldap" {
+	validate_schema = true
+}
+
+resource "ldap_object" "schematest" {
+	dn = "cn=schema,dc=example,dc=com"
+	object_classes = ["%s"]
+	attributes = {%s}
+}
+`, objectClass, attributes)
+}
+
+const testDNOutsideBaseDNConfig = `
+provider "ldap" {
+	base_dn = "ou=people,dc=example,dc=com"
+}
+
+resource "ldap_object" "outsidebasedn" {
+	dn = "cn=outsidebasedn,dc=example,dc=com"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["outsidebasedn"]
+		"sn" = ["test"]
+	}
+}
+`
+
+func testRDNConfig(parentDN string) string {
+	return fmt.Sprintf(`
+resource "ldap_object" "rdntest" {
+	rdn = "cn=Smith, John"
+	%s
+	object_classes = ["person"]
+	attributes = {
+		"cn" = ["Smith, John"]
+		"sn" = ["Smith"]
+	}
+	create_parents = true
+}
+`, parentDN)
+}
+
 const testCreateConfig = `
 resource "ldap_object" "test" {
 	dn = "cn=test,dc=example,dc=com"
@@ -219,6 +1012,40 @@ func testImportPreConfig() {
 	}
 }
 
+const testAdoptConfig = `
+resource "ldap_object" "adopttest" {
+	dn = "cn=adopttest,dc=example,dc=com"
+	object_classes = ["person", "uidObject"]
+	attributes = {
+		"cn" = ["adopttest"]
+		"sn" = ["adopted"]
+		"uid" = ["adopttest"]
+	}
+	on_conflict = "adopt"
+}
+`
+
+func testAdoptPreConfig() {
+	ldapUrl := os.Getenv("LDAP_URL")
+	ldapBindDN := os.Getenv("LDAP_BIND_DN")
+	ldapBindPassword := os.Getenv("LDAP_BIND_PASSWORD")
+
+	if conn, err := ldap.DialURL(ldapUrl); err != nil {
+		return
+	} else {
+		if err := conn.Bind(ldapBindDN, ldapBindPassword); err != nil {
+			return
+		}
+		r := ldap.NewAddRequest("cn=adopttest,dc=example,dc=com", []ldap.Control{})
+		r.Attribute("objectClass", []string{"person"})
+		r.Attribute("sn", []string{"test"})
+		r.Attribute("description", []string{"test"})
+		if err := conn.Add(r); err != nil {
+			return
+		}
+	}
+}
+
 const testImportIgnored = `
 resource "ldap_object" "importtestignore" {
 	dn = "cn=importtestignore,dc=example,dc=com"
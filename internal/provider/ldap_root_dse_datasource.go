@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strconv"
+)
+
+var _ datasource.DataSource = &LDAPRootDSEDataSource{}
+var _ datasource.DataSourceWithConfigure = &LDAPRootDSEDataSource{}
+
+func NewLDAPRootDSEDataSource() datasource.DataSource {
+	return &LDAPRootDSEDataSource{}
+}
+
+type LDAPRootDSEDataSource struct {
+	conn *ldap.Conn
+}
+
+type LDAPRootDSEDataSourceModel struct {
+	Id                      types.String        `tfsdk:"id"`
+	NamingContexts          []string            `tfsdk:"naming_contexts"`
+	DefaultNamingContext    types.String        `tfsdk:"default_naming_context"`
+	SubschemaSubentry       types.String        `tfsdk:"subschema_subentry"`
+	SupportedControls       []string            `tfsdk:"supported_controls"`
+	SupportedExtensions     []string            `tfsdk:"supported_extensions"`
+	SupportedFeatures       []string            `tfsdk:"supported_features"`
+	SupportedSASLMechanisms []string            `tfsdk:"supported_sasl_mechanisms"`
+	SupportedLDAPVersions   []int64             `tfsdk:"supported_ldap_versions"`
+	VendorName              types.String        `tfsdk:"vendor_name"`
+	VendorVersion           types.String        `tfsdk:"vendor_version"`
+	Attributes              map[string][]string `tfsdk:"attributes"`
+}
+
+func (L *LDAPRootDSEDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_root_dse"
+}
+
+func (L *LDAPRootDSEDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	stringList := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Computed:            true,
+		}
+	}
+	stringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Capabilities of the LDAP server announced in its root DSE",
+		Attributes: map[string]schema.Attribute{
+			"id":                        stringAttribute("Datasource identifier"),
+			"naming_contexts":           stringList("DNs of the naming contexts held by the server (`namingContexts`)"),
+			"default_naming_context":    stringAttribute("DN of the default naming context if the server announces one (`defaultNamingContext`)"),
+			"subschema_subentry":        stringAttribute("DN of the subschema subentry (`subschemaSubentry`)"),
+			"supported_controls":        stringList("OIDs of the supported controls (`supportedControl`)"),
+			"supported_extensions":      stringList("OIDs of the supported extended operations (`supportedExtension`)"),
+			"supported_features":        stringList("OIDs of the supported features (`supportedFeatures`)"),
+			"supported_sasl_mechanisms": stringList("Supported SASL mechanisms (`supportedSASLMechanisms`)"),
+			"supported_ldap_versions": schema.ListAttribute{
+				MarkdownDescription: "Supported LDAP protocol versions (`supportedLDAPVersion`)",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"vendor_name":    stringAttribute("Name of the server vendor if the server announces it (`vendorName`)"),
+			"vendor_version": stringAttribute("Version of the server if the server announces it (`vendorVersion`)"),
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "All attributes of the root DSE",
+				Computed:            true,
+				ElementType:         types.ListType{ElemType: types.StringType},
+			},
+		},
+	}
+}
+
+func (L *LDAPRootDSEDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*LDAPProviderData); !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Datasource Configure Type",
+			fmt.Sprintf("Expected *LDAPProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	} else {
+		L.conn = providerData.Conn
+	}
+}
+
+func (L *LDAPRootDSEDataSource) Read(ctx context.Context, _ datasource.ReadRequest, response *datasource.ReadResponse) {
+	entry, err := GetEntry(L.conn, "", "+", "*")
+	if err != nil {
+		AddLDAPError(&response.Diagnostics, "Can not read root DSE", err, types.MapNull(types.ListType{ElemType: types.StringType}))
+		return
+	}
+	tflog.Debug(ctx, "Read root DSE", map[string]interface{}{
+		"entry": ToLDIF(entry),
+	})
+
+	optionalValue := func(attributeType string) types.String {
+		if v := entry.GetEqualFoldAttributeValue(attributeType); v != "" {
+			return types.StringValue(v)
+		}
+		return types.StringNull()
+	}
+	data := LDAPRootDSEDataSourceModel{
+		Id:                      types.StringValue("root_dse"),
+		NamingContexts:          nonNil(entry.GetEqualFoldAttributeValues("namingContexts")),
+		DefaultNamingContext:    optionalValue("defaultNamingContext"),
+		SubschemaSubentry:       optionalValue("subschemaSubentry"),
+		SupportedControls:       nonNil(entry.GetEqualFoldAttributeValues("supportedControl")),
+		SupportedExtensions:     nonNil(entry.GetEqualFoldAttributeValues("supportedExtension")),
+		SupportedFeatures:       nonNil(entry.GetEqualFoldAttributeValues("supportedFeatures")),
+		SupportedSASLMechanisms: nonNil(entry.GetEqualFoldAttributeValues("supportedSASLMechanisms")),
+		SupportedLDAPVersions:   []int64{},
+		VendorName:              optionalValue("vendorName"),
+		VendorVersion:           optionalValue("vendorVersion"),
+		Attributes:              map[string][]string{},
+	}
+	for _, version := range entry.GetEqualFoldAttributeValues("supportedLDAPVersion") {
+		if v, err := strconv.ParseInt(version, 10, 64); err == nil {
+			data.SupportedLDAPVersions = append(data.SupportedLDAPVersions, v)
+		} else {
+			tflog.Warn(ctx, "Ignoring invalid supported LDAP version", map[string]interface{}{"version": version})
+		}
+	}
+	for _, attribute := range entry.Attributes {
+		data.Attributes[attribute.Name] = PrintableValues(attribute)
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestLDAPRootDSEDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testRootDSEDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_root_dse.test", "naming_contexts.0", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_root_dse.test", "subschema_subentry", "cn=Subschema"),
+					resource.TestCheckTypeSetElemAttr("data.ldap_root_dse.test", "supported_ldap_versions.*", "3"),
+					resource.TestCheckTypeSetElemAttr("data.ldap_root_dse.test", "supported_controls.*", "1.2.840.113556.1.4.319"),
+					resource.TestCheckResourceAttr("data.ldap_root_dse.test", "attributes.namingContexts.0", "dc=example,dc=com"),
+				),
+			},
+		},
+	})
+}
+
+const testRootDSEDataSource = `
+data "ldap_root_dse" "test" {
+}`
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = &LDAPSchemaDataSource{}
+var _ datasource.DataSourceWithConfigure = &LDAPSchemaDataSource{}
+
+func NewLDAPSchemaDataSource() datasource.DataSource {
+	return &LDAPSchemaDataSource{}
+}
+
+type LDAPSchemaDataSource struct {
+	schema func() (*Schema, error)
+}
+
+type LDAPSchemaDataSourceModel struct {
+	Id             types.String                            `tfsdk:"id"`
+	ObjectClasses  map[string]LDAPSchemaObjectClassModel   `tfsdk:"object_classes"`
+	AttributeTypes map[string]LDAPSchemaAttributeTypeModel `tfsdk:"attribute_types"`
+}
+
+type LDAPSchemaObjectClassModel struct {
+	OID         string   `tfsdk:"oid"`
+	Names       []string `tfsdk:"names"`
+	Description string   `tfsdk:"description"`
+	Superiors   []string `tfsdk:"superiors"`
+	Kind        string   `tfsdk:"kind"`
+	Must        []string `tfsdk:"must"`
+	May         []string `tfsdk:"may"`
+}
+
+type LDAPSchemaAttributeTypeModel struct {
+	OID                string   `tfsdk:"oid"`
+	Names              []string `tfsdk:"names"`
+	Description        string   `tfsdk:"description"`
+	Superior           string   `tfsdk:"superior"`
+	Syntax             string   `tfsdk:"syntax"`
+	SingleValue        bool     `tfsdk:"single_value"`
+	NoUserModification bool     `tfsdk:"no_user_modification"`
+	Usage              string   `tfsdk:"usage"`
+	Equality           string   `tfsdk:"equality"`
+	Ordering           string   `tfsdk:"ordering"`
+	Substring          string   `tfsdk:"substring"`
+}
+
+func (L *LDAPSchemaDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_schema"
+}
+
+func (L *LDAPSchemaDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	stringList := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Computed:            true,
+		}
+	}
+	stringAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Object classes and attribute types of the schema of the LDAP server",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "DN of the subschema subentry",
+			},
+			"object_classes": schema.MapNestedAttribute{
+				MarkdownDescription: "The object classes of the schema by their first name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"oid":         stringAttribute("OID of the object class"),
+						"names":       stringList("Names of the object class"),
+						"description": stringAttribute("Description of the object class"),
+						"superiors":   stringList("Superior object classes"),
+						"kind":        stringAttribute("Kind of the object class: `ABSTRACT`, `STRUCTURAL` or `AUXILIARY`"),
+						"must":        stringList("Attribute types required by the object class"),
+						"may":         stringList("Attribute types allowed by the object class"),
+					},
+				},
+			},
+			"attribute_types": schema.MapNestedAttribute{
+				MarkdownDescription: "The attribute types of the schema by their first name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"oid":         stringAttribute("OID of the attribute type"),
+						"names":       stringList("Names of the attribute type"),
+						"description": stringAttribute("Description of the attribute type"),
+						"superior":    stringAttribute("Superior attribute type"),
+						"syntax":      stringAttribute("OID of the syntax, including a length limit if any"),
+						"single_value": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute type only allows one value",
+							Computed:            true,
+						},
+						"no_user_modification": schema.BoolAttribute{
+							MarkdownDescription: "Whether the attribute type can only be modified by the server",
+							Computed:            true,
+						},
+						"usage":     stringAttribute("Usage of the attribute type, e.g. `userApplications` or `directoryOperation`"),
+						"equality":  stringAttribute("Equality matching rule"),
+						"ordering":  stringAttribute("Ordering matching rule"),
+						"substring": stringAttribute("Substring matching rule"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (L *LDAPSchemaDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*LDAPProviderData); !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Datasource Configure Type",
+			fmt.Sprintf("Expected *LDAPProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+
+		return
+	} else {
+		L.schema = providerData.Schema
+	}
+}
+
+func (L *LDAPSchemaDataSource) Read(ctx context.Context, _ datasource.ReadRequest, response *datasource.ReadResponse) {
+	s, err := L.schema()
+	if err != nil {
+		AddLDAPError(&response.Diagnostics, "Can not read schema", err, types.MapNull(types.ListType{ElemType: types.StringType}))
+		return
+	}
+	tflog.Debug(ctx, "Read schema", map[string]interface{}{
+		"dn":             s.DN,
+		"objectClasses":  len(s.ObjectClasses),
+		"attributeTypes": len(s.AttributeTypes),
+	})
+
+	data := LDAPSchemaDataSourceModel{
+		Id:             types.StringValue(s.DN),
+		ObjectClasses:  map[string]LDAPSchemaObjectClassModel{},
+		AttributeTypes: map[string]LDAPSchemaAttributeTypeModel{},
+	}
+	for _, c := range s.ObjectClasses {
+		data.ObjectClasses[c.Name()] = LDAPSchemaObjectClassModel{
+			OID:         c.OID,
+			Names:       nonNil(c.Names),
+			Description: c.Description,
+			Superiors:   nonNil(c.Superiors),
+			Kind:        c.Kind,
+			Must:        nonNil(c.Must),
+			May:         nonNil(c.May),
+		}
+	}
+	for _, a := range s.AttributeTypes {
+		data.AttributeTypes[a.Name()] = LDAPSchemaAttributeTypeModel{
+			OID:                a.OID,
+			Names:              nonNil(a.Names),
+			Description:        a.Description,
+			Superior:           a.Superior,
+			Syntax:             a.Syntax,
+			SingleValue:        a.SingleValue,
+			NoUserModification: a.NoUserModification,
+			Usage:              a.Usage,
+			Equality:           a.Equality,
+			Ordering:           a.Ordering,
+			Substring:          a.Substring,
+		}
+	}
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+// nonNil returns an empty list instead of nil, so missing values are exposed as empty lists instead of null.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
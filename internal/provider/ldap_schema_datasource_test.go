@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"testing"
+)
+
+func TestLDAPSchemaDatasource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSchemaDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "id", "cn=Subschema"),
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "object_classes.person.oid", "2.5.6.6"),
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "object_classes.person.kind", "STRUCTURAL"),
+					resource.TestCheckTypeSetElemAttr("data.ldap_schema.test", "object_classes.person.must.*", "sn"),
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "attribute_types.cn.superior", "name"),
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "attribute_types.displayName.single_value", "true"),
+					resource.TestCheckResourceAttr("data.ldap_schema.test", "attribute_types.entryUUID.usage", "directoryOperation"),
+				),
+			},
+		},
+	})
+}
+
+const testSchemaDataSource = `
+data "ldap_schema" "test" {
+}`
@@ -4,24 +4,29 @@ import (
 	"context"
 	"fmt"
 	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ datasource.DataSource = &LDAPSearchDataSource{}
 var _ datasource.DataSourceWithConfigure = &LDAPSearchDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &LDAPSearchDataSource{}
 
 func NewLDAPSearchDataSource() datasource.DataSource {
 	return &LDAPSearchDataSource{}
 }
 
 type LDAPSearchDataSource struct {
-	conn *ldap.Conn
+	conn          Searcher
+	defaultBaseDN func() (string, error)
 }
 
 type LDAPSearchDatasourceModel struct {
@@ -29,25 +34,72 @@ type LDAPSearchDatasourceModel struct {
 	BaseDN               types.String `tfsdk:"base_dn"`
 	Scope                types.String `tfsdk:"scope"`
 	Filter               types.String `tfsdk:"filter"`
+	Match                types.Set    `tfsdk:"match"`
+	MatchGroups          types.List   `tfsdk:"match_groups"`
+	MatchMode            types.String `tfsdk:"match_mode"`
 	Results              types.List   `tfsdk:"results"`
+	Entries              types.List   `tfsdk:"entries"`
+	DNs                  types.List   `tfsdk:"dns"`
+	ResultsByDN          types.Map    `tfsdk:"results_by_dn"`
 	AdditionalAttributes types.Set    `tfsdk:"additional_attributes"`
+	RequestedAttributes  types.Set    `tfsdk:"requested_attributes"`
+	DerefAliases         types.String `tfsdk:"deref_aliases"`
+	PageSize             types.Int64  `tfsdk:"page_size"`
+	SizeLimit            types.Int64  `tfsdk:"size_limit"`
+	TimeLimit            types.Int64  `tfsdk:"time_limit"`
+	SortBy               types.List   `tfsdk:"sort_by"`
 }
 
+// LDAPSearchSortKeyModel describes a sort key of the search.
+type LDAPSearchSortKeyModel struct {
+	Attribute    types.String `tfsdk:"attribute"`
+	Reverse      types.Bool   `tfsdk:"reverse"`
+	MatchingRule types.String `tfsdk:"matching_rule"`
+}
+
+// LDAPSearchMatchModel describes a comparison the searched objects have to match.
+type LDAPSearchMatchModel struct {
+	Attribute types.String `tfsdk:"attribute"`
+	Operator  types.String `tfsdk:"operator"`
+	Value     types.String `tfsdk:"value"`
+	Not       types.Bool   `tfsdk:"not"`
+}
+
+// LDAPSearchMatchGroupModel describes a group of comparisons.
+type LDAPSearchMatchGroupModel struct {
+	Match types.Set    `tfsdk:"match"`
+	Mode  types.String `tfsdk:"mode"`
+	Not   types.Bool   `tfsdk:"not"`
+}
+
+// LDAPSearchEntryModel describes an entry found by the search.
+type LDAPSearchEntryModel struct {
+	DN            string              `tfsdk:"dn"`
+	ObjectClasses []string            `tfsdk:"object_classes"`
+	Attributes    map[string][]string `tfsdk:"attributes"`
+}
+
+// defaultPageSize is the number of entries fetched per page if page_size isn't set.
+const defaultPageSize = 500
+
 func (L *LDAPSearchDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_search"
 }
 
 func (L *LDAPSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
 	response.Schema = schema.Schema{
-		MarkdownDescription: "Generic LDAP search datasource",
+		MarkdownDescription: "Generic LDAP search datasource. Large multi-valued attributes the server only returns " +
+			"in ranges, like `member;range=0-1499` in Active Directory, are fetched completely and merged into one " +
+			"attribute without the range option",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Datasource identifier",
 			},
 			"base_dn": schema.StringAttribute{
-				MarkdownDescription: "Base DN to use to search for LDAP objects",
+				MarkdownDescription: "Base DN to use to search for LDAP objects. Defaults to the base DN of the provider",
 				Optional:            true,
+				Computed:            true,
 			},
 			"scope": schema.StringAttribute{
 				MarkdownDescription: "Scope to use to search for LDAP objects",
@@ -57,14 +109,107 @@ func (L *LDAPSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				},
 			},
 			"filter": schema.StringAttribute{
-				MarkdownDescription: "Filter to search for LDAP objects with",
+				MarkdownDescription: "Filter to search for LDAP objects with. Combined with `match` using `and` if both are set",
 				Optional:            true,
+				Validators: []validator.String{
+					Filter(),
+				},
+			},
+			"match": matchAttribute("Comparisons the LDAP objects have to match. The values are escaped, so " +
+				"they may contain special characters like `*` or `(`"),
+			"match_groups": schema.ListNestedAttribute{
+				MarkdownDescription: "Groups of comparisons which are combined like a single comparison in `match`",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"match": matchAttribute("Comparisons of the group"),
+						"mode": schema.StringAttribute{
+							MarkdownDescription: "Whether the objects have to match `and` (default) or `or` of the comparisons of the group",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("and", "or"),
+							},
+						},
+						"not": schema.BoolAttribute{
+							MarkdownDescription: "Whether to negate the group",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"match_mode": schema.StringAttribute{
+				MarkdownDescription: "Whether the objects have to match `and` (default) or `or` of the comparisons in " +
+					"`match` and the groups in `match_groups`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("and", "or"),
+				},
+			},
+			"requested_attributes": schema.SetAttribute{
+				MarkdownDescription: "The attribute types to request instead of all user attributes (`*`) " +
+					"in addition to `additional_attributes`. Use `[\"1.1\"]` to request no attributes at all",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"deref_aliases": schema.StringAttribute{
+				MarkdownDescription: "When to dereference aliases: `never` (default), `searching` (only below the base " +
+					"DN), `finding` (only the base DN) or `always`",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("never", "searching", "finding", "always"),
+				},
 			},
 			"additional_attributes": schema.SetAttribute{
 				MarkdownDescription: "Any additional attributes to request, such as constructed or operational attributes",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of entries to fetch per page using the paged results control. "+
+					"Defaults to %d, 0 disables paging", defaultPageSize),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"size_limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of entries to return. If more entries match, the first ones are " +
+					"returned with a warning. If the results of `sort_by` are sorted on the client, all matching " +
+					"entries are fetched to return the first ones in sort order",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"time_limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds the server may spend on the search",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"sort_by": schema.ListNestedAttribute{
+				MarkdownDescription: "Sort the results by these keys using the server side sorting control. If the " +
+					"server doesn't support it, the results are sorted by the first values of the attributes, ignoring " +
+					"case and matching rules. Results are sorted by their DN if not set",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"attribute": schema.StringAttribute{
+							MarkdownDescription: "The attribute type to sort by",
+							Required:            true,
+						},
+						"reverse": schema.BoolAttribute{
+							MarkdownDescription: "Whether to sort in descending order",
+							Optional:            true,
+						},
+						"matching_rule": schema.StringAttribute{
+							MarkdownDescription: "The ordering matching rule to sort with on the server",
+							Optional:            true,
+						},
+					},
+				},
+			},
 			"results": schema.ListAttribute{
 				MarkdownDescription: "List of LDAP objects returned from the search",
 				Computed:            true,
@@ -72,6 +217,80 @@ func (L *LDAPSearchDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 					ElemType: types.ListType{ElemType: types.StringType},
 				},
 			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "List of LDAP objects returned from the search including their DN",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: searchEntryAttributes(),
+				},
+			},
+			"dns": schema.ListAttribute{
+				MarkdownDescription: "List of the DNs of the LDAP objects returned from the search",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"results_by_dn": schema.MapNestedAttribute{
+				MarkdownDescription: "The LDAP objects returned from the search by their DN",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: searchEntryAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// matchAttribute returns the schema of a set of comparisons.
+func matchAttribute(description string) schema.SetNestedAttribute {
+	return schema.SetNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"attribute": schema.StringAttribute{
+					MarkdownDescription: "The attribute type to compare",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(AttributeDescriptionPattern, "must be an attribute type name or OID"),
+					},
+				},
+				"operator": schema.StringAttribute{
+					MarkdownDescription: "How to compare the attribute: `equality` (default), `presence`, `substring` (the " +
+						"value is contained in the attribute), `starts_with`, `ends_with`, `greater_or_equal` or `less_or_equal`",
+					Optional: true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("equality", "presence", "substring", "starts_with", "ends_with", "greater_or_equal", "less_or_equal"),
+					},
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "The value to compare with. Required unless the operator is `presence`",
+					Optional:            true,
+				},
+				"not": schema.BoolAttribute{
+					MarkdownDescription: "Whether to negate the comparison",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+// searchEntryAttributes returns the schema of an entry found by the search.
+func searchEntryAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dn": schema.StringAttribute{
+			MarkdownDescription: "DN of the ldap object",
+			Computed:            true,
+		},
+		"object_classes": schema.ListAttribute{
+			MarkdownDescription: "A list of classes the object implements",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"attributes": schema.MapAttribute{
+			MarkdownDescription: "The attributes of the object except objectClass",
+			Computed:            true,
+			ElementType:         types.ListType{ElemType: types.StringType},
 		},
 	}
 }
@@ -81,16 +300,109 @@ func (L *LDAPSearchDataSource) Configure(_ context.Context, request datasource.C
 		return
 	}
 
-	if conn, ok := request.ProviderData.(*ldap.Conn); !ok {
+	if providerData, ok := request.ProviderData.(*LDAPProviderData); !ok {
 		response.Diagnostics.AddError(
 			"Unexpected Datasource Configure Type",
-			fmt.Sprintf("Expected *ldap.Conn, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *LDAPProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 
 		return
 	} else {
-		L.conn = conn
+		L.conn = providerData.Searcher()
+		L.defaultBaseDN = providerData.DefaultBaseDN
+	}
+}
+
+func (L *LDAPSearchDataSource) ValidateConfig(ctx context.Context, request datasource.ValidateConfigRequest, response *datasource.ValidateConfigResponse) {
+	var match types.Set
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("match"), &match)...)
+	validateMatches(ctx, match, path.Root("match"), &response.Diagnostics)
+
+	var groups types.List
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("match_groups"), &groups)...)
+	for i, group := range groups.Elements() {
+		if group, ok := group.(types.Object); ok && !group.IsNull() && !group.IsUnknown() {
+			if match, ok := group.Attributes()["match"].(types.Set); ok {
+				validateMatches(ctx, match, path.Root("match_groups").AtListIndex(i).AtName("match"), &response.Diagnostics)
+			}
+		}
+	}
+}
+
+// validateMatches checks that the comparisons have a value unless they check for presence. Unknown comparisons are
+// skipped.
+func validateMatches(ctx context.Context, matches types.Set, p path.Path, diagnostics *diag.Diagnostics) {
+	for _, element := range matches.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+		var match LDAPSearchMatchModel
+		diagnostics.Append(object.As(ctx, &match, basetypes.ObjectAsOptions{})...)
+		if match.Operator.IsUnknown() || match.Value.IsUnknown() {
+			continue
+		}
+		if match.Value.IsNull() && match.Operator.ValueString() != "presence" {
+			diagnostics.AddAttributeError(
+				p,
+				"Missing value",
+				fmt.Sprintf("The comparison of attribute %s requires a value unless its operator is presence", match.Attribute.ValueString()),
+			)
+		}
+	}
+}
+
+// matchFilters returns the filters of the comparisons.
+func matchFilters(ctx context.Context, matches types.Set, p path.Path, diagnostics *diag.Diagnostics) []string {
+	var models []LDAPSearchMatchModel
+	diagnostics.Append(matches.ElementsAs(ctx, &models, false)...)
+	var filters []string
+	for _, match := range models {
+		filter, err := FilterMatch{
+			Attribute: match.Attribute.ValueString(),
+			Operator:  match.Operator.ValueString(),
+			Value:     match.Value.ValueString(),
+			Not:       match.Not.ValueBool(),
+		}.Filter()
+		if err != nil {
+			diagnostics.AddAttributeError(p, "Invalid comparison", err.Error())
+			continue
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// searchFilter returns the filter of the search combining the raw filter, the comparisons and the groups of
+// comparisons.
+func searchFilter(ctx context.Context, data LDAPSearchDatasourceModel, diagnostics *diag.Diagnostics) string {
+	var filters []string
+	if !data.Filter.IsUnknown() && !data.Filter.IsNull() {
+		filters = append(filters, data.Filter.ValueString())
+	}
+
+	comparisons := matchFilters(ctx, data.Match, path.Root("match"), diagnostics)
+	var groups []LDAPSearchMatchGroupModel
+	diagnostics.Append(data.MatchGroups.ElementsAs(ctx, &groups, false)...)
+	for i, group := range groups {
+		groupFilters := matchFilters(ctx, group.Match, path.Root("match_groups").AtListIndex(i).AtName("match"), diagnostics)
+		if len(groupFilters) == 0 {
+			continue
+		}
+		filter := CombineFilters(group.Mode.ValueString(), groupFilters...)
+		if group.Not.ValueBool() {
+			filter = fmt.Sprintf("(!%s)", filter)
+		}
+		comparisons = append(comparisons, filter)
+	}
+	if len(comparisons) > 0 {
+		filters = append(filters, CombineFilters(data.MatchMode.ValueString(), comparisons...))
+	}
+
+	if len(filters) == 0 {
+		return "(&)"
 	}
+	return CombineFilters("and", filters...)
 }
 
 func (L *LDAPSearchDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
@@ -99,6 +411,21 @@ func (L *LDAPSearchDataSource) Read(ctx context.Context, request datasource.Read
 
 	var additionalAttributes []string
 	response.Diagnostics.Append(data.AdditionalAttributes.ElementsAs(ctx, &additionalAttributes, false)...)
+	attributeTypes := RequestedAttributeTypes(ctx, data.RequestedAttributes, additionalAttributes, &response.Diagnostics)
+
+	if data.BaseDN.IsNull() {
+		if baseDN, err := L.defaultBaseDN(); err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("base_dn"),
+				"Can not determine base DN",
+				fmt.Sprintf("Discovering the base DN of the server failed: %s", err),
+			)
+			return
+		} else {
+			data.BaseDN = types.StringValue(baseDN)
+			response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("base_dn"), baseDN)...)
+		}
+	}
 
 	var scope int
 
@@ -115,39 +442,124 @@ func (L *LDAPSearchDataSource) Read(ctx context.Context, request datasource.Read
 		}
 	}
 
-	var filter string
-
-	if data.Filter.IsUnknown() || data.Filter.IsNull() {
-		filter = "(&)"
-	} else {
-		filter = data.Filter.ValueString()
+	filter := searchFilter(ctx, data, &response.Diagnostics)
+	if response.Diagnostics.HasError() {
+		return
 	}
 
 	response.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s/%s/%s", data.BaseDN.ValueString(), data.Scope.ValueString(), filter))
 
 	tflog.Debug(ctx, "Searching for ldap entries", map[string]interface{}{
-		"baseDN":               data.BaseDN.ValueString(),
-		"scope":                scope,
-		"filter":               filter,
-		"additionalAttributes": additionalAttributes,
+		"baseDN":     data.BaseDN.ValueString(),
+		"scope":      scope,
+		"filter":     filter,
+		"attributes": attributeTypes,
 	})
 
-	s := ldap.NewSearchRequest(data.BaseDN.ValueString(), scope, 0, 0, 0, false, filter, append(additionalAttributes, "*"), []ldap.Control{})
+	s := ldap.NewSearchRequest(
+		data.BaseDN.ValueString(),
+		scope,
+		DerefAliases[data.DerefAliases.ValueString()],
+		int(data.SizeLimit.ValueInt64()),
+		int(data.TimeLimit.ValueInt64()),
+		false,
+		filter,
+		attributeTypes,
+		[]ldap.Control{},
+	)
 
-	if result, err := L.conn.Search(s); err != nil {
-		response.Diagnostics.AddError(
-			"Can not read entry",
-			err.Error(),
-		)
+	var sortBy []LDAPSearchSortKeyModel
+	response.Diagnostics.Append(data.SortBy.ElementsAs(ctx, &sortBy, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	var sortKeys []*ldap.SortKey
+	for _, key := range sortBy {
+		sortKeys = append(sortKeys, &ldap.SortKey{
+			AttributeType: key.Attribute.ValueString(),
+			Reverse:       key.Reverse.ValueBool(),
+			MatchingRule:  key.MatchingRule.ValueString(),
+		})
+	}
+	serverSideSorting := len(sortKeys) > 0 && SupportsControl(L.conn, ldap.ControlTypeServerSideSorting)
+	if serverSideSorting {
+		s.Controls = append(s.Controls, ldap.NewControlServerSideSortingWithSortKeys(sortKeys))
+	}
+	// the server would return an arbitrary subset, so the first entries in sort order are selected after sorting
+	clientSideLimit := len(sortKeys) > 0 && !serverSideSorting && !data.SizeLimit.IsNull()
+	if clientSideLimit {
+		s.SizeLimit = 0
+	}
+
+	pageSize := int64(defaultPageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+
+	var result *ldap.SearchResult
+	var err error
+	if pageSize > 0 {
+		result, err = L.conn.SearchWithPaging(s, uint32(pageSize))
+	} else {
+		result, err = L.conn.Search(s)
+	}
+	sizeLimitExceeded := false
+	if err != nil && ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) && !data.SizeLimit.IsNull() && result != nil {
+		sizeLimitExceeded = true
+		err = nil
+	}
+
+	if err != nil {
+		AddLDAPError(&response.Diagnostics, "Can not read entry", err, types.MapNull(types.ListType{ElemType: types.StringType}))
 	} else {
+		for _, entry := range result.Entries {
+			if err := ResolveRangedAttributes(L.conn, entry); err != nil {
+				AddLDAPError(&response.Diagnostics, "Can not read entry", err, types.MapNull(types.ListType{ElemType: types.StringType}))
+				return
+			}
+		}
+		if !serverSideSorting {
+			tflog.Debug(ctx, "Sorting results on the client")
+			SortEntries(result.Entries, sortKeys)
+		}
+		if !data.SizeLimit.IsNull() && len(result.Entries) > int(data.SizeLimit.ValueInt64()) {
+			sizeLimitExceeded = true
+			result.Entries = result.Entries[:data.SizeLimit.ValueInt64()]
+		}
+		if sizeLimitExceeded {
+			response.Diagnostics.AddAttributeWarning(
+				path.Root("size_limit"),
+				"Size limit exceeded",
+				fmt.Sprintf("More than %d entries match the search, only the first ones are returned", data.SizeLimit.ValueInt64()),
+			)
+		}
+		entries := []LDAPSearchEntryModel{}
+		dns := []string{}
+		resultsByDN := map[string]LDAPSearchEntryModel{}
 		for i, entry := range result.Entries {
 			ctx := MaskAttributesFromArray(ctx, entry.Attributes)
 			tflog.Debug(ctx, "Found entry", map[string]interface{}{
 				"entry": ToLDIF(entry),
 			})
+			e := LDAPSearchEntryModel{
+				DN:            entry.DN,
+				ObjectClasses: []string{},
+				Attributes:    map[string][]string{},
+			}
 			for _, attribute := range entry.Attributes {
 				response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("results").AtListIndex(i).AtMapKey(attribute.Name), attribute.Values)...)
+				if attribute.Name == "objectClass" {
+					e.ObjectClasses = attribute.Values
+				} else {
+					e.Attributes[attribute.Name] = attribute.Values
+				}
 			}
+			entries = append(entries, e)
+			dns = append(dns, entry.DN)
+			resultsByDN[entry.DN] = e
 		}
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("entries"), entries)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("dns"), dns)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("results_by_dn"), resultsByDN)...)
 	}
 }
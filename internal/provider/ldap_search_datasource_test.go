@@ -2,6 +2,7 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
 	"testing"
 )
 
@@ -18,12 +19,311 @@ func TestLDAPSearchDatasource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.ldap_search.test", "base_dn", "dc=example,dc=com"),
 					resource.TestCheckResourceAttr("data.ldap_search.test", "results.0.dc.0", "example"),
 					resource.TestCheckResourceAttr("data.ldap_search.test", "results.0.creatorsName.0", "cn=admin,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "entries.0.dn", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "entries.0.object_classes.#", "3"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "entries.0.attributes.dc.0", "example"),
+					resource.TestCheckNoResourceAttr("data.ldap_search.test", "entries.0.attributes.objectClass.0"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "dns.#", "1"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "dns.0", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "results_by_dn.dc=example,dc=com.attributes.dc.0", "example"),
 				),
 			},
 		},
 	})
 }
 
+func TestLDAPSearchDatasourceDefaultBaseDN(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSearchDataSourceDefaultBaseDN,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_search.test", "base_dn", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.test", "results.0.dc.0", "example"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPSearchDatasourceLimits(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSearchDataSourceLimits,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_search.paged", "results.0.dc.0", "example"),
+					resource.TestCheckResourceAttrSet("data.ldap_search.paged", "results.1.objectClass.0"),
+					resource.TestCheckResourceAttr("data.ldap_search.limited", "results.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPSearchDatasourceRequestedAttributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSearchDataSourceRequestedAttributes,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_search.dns", "dns.0", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.dns", "entries.0.attributes.%", "0"),
+					resource.TestCheckResourceAttr("data.ldap_search.dc", "entries.0.attributes.%", "2"),
+					resource.TestCheckResourceAttr("data.ldap_search.dc", "entries.0.attributes.dc.0", "example"),
+					resource.TestCheckResourceAttr("data.ldap_search.dc", "entries.0.attributes.creatorsName.0", "cn=admin,dc=example,dc=com"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPSearchDatasourceSortBy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testSearchDataSourceSortBy,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_search.default", "dns.#", "3"),
+					resource.TestCheckResourceAttr("data.ldap_search.default", "dns.0", "cn=a,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.default", "dns.1", "cn=b,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.default", "dns.2", "cn=c,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.sorted", "dns.#", "3"),
+					resource.TestCheckResourceAttr("data.ldap_search.sorted", "dns.0", "cn=b,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.sorted", "dns.1", "cn=c,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.sorted", "dns.2", "cn=a,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.reversed", "dns.#", "3"),
+					resource.TestCheckResourceAttr("data.ldap_search.reversed", "dns.0", "cn=a,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.reversed", "dns.1", "cn=c,ou=sortby,dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.reversed", "dns.2", "cn=b,ou=sortby,dc=example,dc=com"),
+				),
+			},
+		},
+	})
+}
+
+func TestLDAPSearchDatasourceMatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testSearchDataSourceInvalidFilter,
+				ExpectError: regexp.MustCompile("Invalid filter"),
+			},
+			{
+				Config:      testSearchDataSourceMissingMatchValue,
+				ExpectError: regexp.MustCompile("Missing value"),
+			},
+			{
+				Config: testSearchDataSourceMatch,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ldap_search.match", "dns.#", "1"),
+					resource.TestCheckResourceAttr("data.ldap_search.match", "dns.0", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.escaped", "dns.#", "0"),
+					resource.TestCheckResourceAttr("data.ldap_search.grouped", "dns.#", "1"),
+					resource.TestCheckResourceAttr("data.ldap_search.grouped", "dns.0", "dc=example,dc=com"),
+					resource.TestCheckResourceAttr("data.ldap_search.negated", "dns.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testSearchDataSourceInvalidFilter = `
+data "ldap_search" "test" {
+	base_dn = "dc=example,dc=com"
+	filter = "(dc=example"
+}`
+
+const testSearchDataSourceMissingMatchValue = `
+data "ldap_search" "test" {
+	base_dn = "dc=example,dc=com"
+	match = [
+		{
+			attribute = "dc"
+		}
+	]
+}`
+
+const testSearchDataSourceMatch = `
+data "ldap_search" "match" {
+	base_dn = "dc=example,dc=com"
+	scope = "wholeSubtree"
+	filter = "(objectClass=*)"
+	match_mode = "or"
+	match = [
+		{
+			attribute = "dc"
+			value = "example"
+		},
+		{
+			attribute = "dc"
+			operator = "substring"
+			value = "xampl"
+		}
+	]
+}
+
+data "ldap_search" "escaped" {
+	base_dn = "dc=example,dc=com"
+	scope = "wholeSubtree"
+	match = [
+		{
+			attribute = "dc"
+			value = "*"
+		}
+	]
+}
+
+data "ldap_search" "grouped" {
+	base_dn = "dc=example,dc=com"
+	scope = "wholeSubtree"
+	match = [
+		{
+			attribute = "objectClass"
+			operator = "presence"
+		}
+	]
+	match_groups = [
+		{
+			mode = "or"
+			match = [
+				{
+					attribute = "dc"
+					operator = "starts_with"
+					value = "exa"
+				},
+				{
+					attribute = "dc"
+					operator = "ends_with"
+					value = "nomatch"
+				}
+			]
+		}
+	]
+}
+
+data "ldap_search" "negated" {
+	base_dn = "dc=example,dc=com"
+	match_groups = [
+		{
+			not = true
+			match = [
+				{
+					attribute = "dc"
+					value = "example"
+				}
+			]
+		}
+	]
+}`
+
+const testSearchDataSourceSortBy = `
+resource "ldap_object" "sortby" {
+	dn = "ou=sortby,dc=example,dc=com"
+	object_classes = ["organizationalUnit"]
+	attributes = {
+		"ou" = ["sortby"]
+	}
+}
+
+resource "ldap_object" "sortby_entries" {
+	for_each = {
+		"a" = "charlie"
+		"b" = "alpha"
+		"c" = "bravo"
+	}
+	dn = "cn=${each.key},${ldap_object.sortby.dn}"
+	object_classes = ["person"]
+	attributes = {
+		"cn" = [each.key]
+		"sn" = [each.value]
+	}
+}
+
+data "ldap_search" "default" {
+	base_dn = ldap_object.sortby.dn
+	scope = "singleLevel"
+	requested_attributes = ["1.1"]
+	depends_on = [ldap_object.sortby_entries]
+}
+
+data "ldap_search" "sorted" {
+	base_dn = ldap_object.sortby.dn
+	scope = "singleLevel"
+	requested_attributes = ["sn"]
+	sort_by = [
+		{
+			attribute = "sn"
+		}
+	]
+	depends_on = [ldap_object.sortby_entries]
+}
+
+data "ldap_search" "reversed" {
+	base_dn = ldap_object.sortby.dn
+	scope = "singleLevel"
+	requested_attributes = ["sn"]
+	sort_by = [
+		{
+			attribute = "sn"
+			reverse = true
+		}
+	]
+	depends_on = [ldap_object.sortby_entries]
+}`
+
+const testSearchDataSourceRequestedAttributes = `
+data "ldap_search" "dns" {
+	base_dn = "dc=example,dc=com"
+	requested_attributes = ["1.1"]
+}
+
+data "ldap_search" "dc" {
+	base_dn = "dc=example,dc=com"
+	requested_attributes = ["dc"]
+	additional_attributes = ["creatorsName"]
+}`
+
+const testSearchDataSourceLimits = `
+data "ldap_search" "paged" {
+	base_dn = "dc=example,dc=com"
+	scope = "wholeSubtree"
+	page_size = 1
+	deref_aliases = "always"
+}
+
+data "ldap_search" "limited" {
+	base_dn = "dc=example,dc=com"
+	scope = "wholeSubtree"
+	size_limit = 1
+	time_limit = 10
+}`
+
+const testSearchDataSourceDefaultBaseDN = `
+data "ldap_search" "test" {
+	scope = "baseObject"
+}`
+
 const testSearchDataSource = `
 data "ldap_search" "test" {
 	base_dn = "dc=example,dc=com"
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ planmodifier.Map = useStateAfterCreateModifier{}
+
+// useStateAfterCreateModifier keeps the value of the state once the resource exists.
+type useStateAfterCreateModifier struct{}
+
+func (m useStateAfterCreateModifier) Description(_ context.Context) string {
+	return "changes of the value after the resource was created are ignored"
+}
+
+func (m useStateAfterCreateModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateAfterCreateModifier) PlanModifyMap(ctx context.Context, request planmodifier.MapRequest, response *planmodifier.MapResponse) {
+	if request.State.Raw.IsNull() {
+		// the attribute is computed, so an unset value has to be planned explicitly on create
+		if request.ConfigValue.IsNull() {
+			response.PlanValue = types.MapNull(request.PlanValue.ElementType(ctx))
+		}
+		return
+	}
+	response.PlanValue = request.StateValue
+}
+
+// UseStateAfterCreate returns a plan modifier which keeps the value of the state once the resource exists, so
+// changes of the value don't cause updates. The attribute has to be computed.
+func UseStateAfterCreate() planmodifier.Map {
+	return useStateAfterCreateModifier{}
+}
@@ -5,15 +5,19 @@ import (
 	"crypto/tls"
 	"fmt"
 	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"log"
+	"net/url"
 	"os"
 	"strings"
+	"sync"
 )
 
 // Ensure LDAPProvider satisfies various provider interfaces.
@@ -34,6 +38,91 @@ type LDAPProviderModel struct {
 	LDAPBindPassword      types.String `tfsdk:"ldap_bind_password"`
 	LDAPTLSInsecureVerify types.Bool   `tfsdk:"ldap_tls_insecure_verify"`
 	LDAPTLSUseStartTLS    types.Bool   `tfsdk:"ldap_tls_use_starttls"`
+	DefaultIgnoreChanges  types.List   `tfsdk:"default_ignore_changes"`
+	BackupDir             types.String `tfsdk:"backup_dir"`
+	ValidateSchema        types.Bool   `tfsdk:"validate_schema"`
+	BaseDN                types.String `tfsdk:"base_dn"`
+	FollowReferrals       types.Bool   `tfsdk:"follow_referrals"`
+	ReferralCredentials   types.Bool   `tfsdk:"referral_credentials"`
+}
+
+// LDAPProviderData is handed to the resources and data sources of the provider.
+type LDAPProviderData struct {
+	Conn                 *ldap.Conn
+	DefaultIgnoreChanges []string
+	BackupDir            string
+	ValidateSchema       bool
+	// BaseDN is the configured base DN, use DefaultBaseDN to get the discovered one if it isn't configured
+	BaseDN string
+	// FollowReferrals makes Searcher follow referrals returned by the server
+	FollowReferrals bool
+	// ReferralCredentials makes Searcher bind to referred servers with the bind credentials instead of anonymously
+	ReferralCredentials bool
+
+	serverURL    string
+	dialOptions  []ldap.DialOpt
+	startTLS     *tls.Config
+	bindDN       string
+	bindPassword string
+
+	schema     *Schema
+	schemaErr  error
+	schemaOnce sync.Once
+
+	rootDSE     ldap.Entry
+	rootDSEErr  error
+	rootDSEOnce sync.Once
+}
+
+// Searcher returns the connection used by the data sources to search. It follows referrals if configured.
+func (d *LDAPProviderData) Searcher() Searcher {
+	if !d.FollowReferrals {
+		return d.Conn
+	}
+	return &ReferralConn{
+		Conn:            d.Conn,
+		serverURL:       d.serverURL,
+		dialOptions:     d.dialOptions,
+		startTLS:        d.startTLS,
+		bindCredentials: d.ReferralCredentials,
+		bindDN:          d.bindDN,
+		bindPassword:    d.bindPassword,
+	}
+}
+
+// readRootDSE reads the naming contexts of the server from its root DSE. They are only read once per provider.
+func (d *LDAPProviderData) readRootDSE() (ldap.Entry, error) {
+	d.rootDSEOnce.Do(func() {
+		d.rootDSE, d.rootDSEErr = GetEntry(d.Conn, "", "namingContexts", "defaultNamingContext")
+	})
+	return d.rootDSE, d.rootDSEErr
+}
+
+// DefaultBaseDN returns the configured base DN or discovers it from the defaultNamingContext or the first
+// namingContexts value of the root DSE.
+func (d *LDAPProviderData) DefaultBaseDN() (string, error) {
+	if d.BaseDN != "" {
+		return d.BaseDN, nil
+	}
+	rootDSE, err := d.readRootDSE()
+	if err != nil {
+		return "", err
+	}
+	if v := rootDSE.GetEqualFoldAttributeValue("defaultNamingContext"); v != "" {
+		return v, nil
+	}
+	if v := rootDSE.GetEqualFoldAttributeValues("namingContexts"); len(v) > 0 {
+		return v[0], nil
+	}
+	return "", fmt.Errorf("the root DSE of the server doesn't announce a naming context, configure base_dn for the provider")
+}
+
+// Schema returns the subschema of the server. It is only read once per provider.
+func (d *LDAPProviderData) Schema() (*Schema, error) {
+	d.schemaOnce.Do(func() {
+		d.schema, d.schemaErr = GetSchema(d.Conn)
+	})
+	return d.schema, d.schemaErr
 }
 
 func (p *LDAPProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -71,6 +160,45 @@ All provider options can be set by the respective environment variables as well.
 				MarkdownDescription: "Whether to connect using STARTTLS (`LDAP_TLS_USE_STARTTLS`)",
 				Optional:            true,
 			},
+			"default_ignore_changes": schema.ListAttribute{
+				MarkdownDescription: "A list of types for which changes are ignored in every `ldap_object` in addition to its " +
+					"own `ignore_changes`. Supports the same patterns as `ignore_changes` " +
+					"(`LDAP_DEFAULT_IGNORE_CHANGES`, comma separated)",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(AttributePattern()),
+				},
+			},
+			"backup_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory to write LDIF backups of entries to before they are deleted or recreated " +
+					"(`LDAP_BACKUP_DIR`)",
+				Optional: true,
+			},
+			"base_dn": schema.StringAttribute{
+				MarkdownDescription: "Base DN used by `ldap_search` if it doesn't set one and as the parent of `ldap_object` " +
+					"resources which set `rdn` without `parent_dn`. Discovered from " +
+					"the `defaultNamingContext` or `namingContexts` of the root DSE if not set (`LDAP_BASE_DN`)",
+				Optional: true,
+			},
+			"follow_referrals": schema.BoolAttribute{
+				MarkdownDescription: "Whether the data sources follow referrals to other servers using the same scheme " +
+					"and TLS settings. Resources don't follow referrals, because they only modify entries on the " +
+					"configured server (`LDAP_FOLLOW_REFERRALS`)",
+				Optional: true,
+			},
+			"referral_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Whether to bind to the servers of followed referrals with the bind DN and password " +
+					"instead of anonymously. Only enable it if all referred servers are trusted " +
+					"(`LDAP_REFERRAL_CREDENTIALS`)",
+				Optional: true,
+			},
+			"validate_schema": schema.BoolAttribute{
+				MarkdownDescription: "Whether to validate the attributes of `ldap_object` resources against the schema of the " +
+					"server while planning. Defaults to true, set it to false to skip the validation, e.g. if the " +
+					"schema of the server is incomplete (`LDAP_VALIDATE_SCHEMA`)",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -90,6 +218,32 @@ func (p *LDAPProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		ldapTLSUseStartTLS = strings.ToUpper(v) == "TRUE"
 	}
 
+	var defaultIgnoreChanges []string
+	if v := os.Getenv("LDAP_DEFAULT_IGNORE_CHANGES"); v != "" {
+		for _, pattern := range strings.Split(v, ",") {
+			defaultIgnoreChanges = append(defaultIgnoreChanges, strings.TrimSpace(pattern))
+		}
+	}
+
+	backupDir := os.Getenv("LDAP_BACKUP_DIR")
+
+	baseDN := os.Getenv("LDAP_BASE_DN")
+
+	followReferrals := false
+	if v := os.Getenv("LDAP_FOLLOW_REFERRALS"); v != "" {
+		followReferrals = strings.ToUpper(v) == "TRUE"
+	}
+
+	referralCredentials := false
+	if v := os.Getenv("LDAP_REFERRAL_CREDENTIALS"); v != "" {
+		referralCredentials = strings.ToUpper(v) == "TRUE"
+	}
+
+	validateSchema := true
+	if v := os.Getenv("LDAP_VALIDATE_SCHEMA"); v != "" {
+		validateSchema = strings.ToUpper(v) == "TRUE"
+	}
+
 	var data LDAPProviderModel
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -116,6 +270,34 @@ func (p *LDAPProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		ldapTLSUseStartTLS = data.LDAPTLSUseStartTLS.ValueBool()
 	}
 
+	if data.BackupDir.ValueString() != "" {
+		backupDir = data.BackupDir.ValueString()
+	}
+
+	if data.BaseDN.ValueString() != "" {
+		baseDN = data.BaseDN.ValueString()
+	}
+
+	if !data.FollowReferrals.IsNull() {
+		followReferrals = data.FollowReferrals.ValueBool()
+	}
+
+	if !data.ReferralCredentials.IsNull() {
+		referralCredentials = data.ReferralCredentials.ValueBool()
+	}
+
+	if !data.ValidateSchema.IsNull() {
+		validateSchema = data.ValidateSchema.ValueBool()
+	}
+
+	if !data.DefaultIgnoreChanges.IsNull() {
+		defaultIgnoreChanges = []string{}
+		resp.Diagnostics.Append(data.DefaultIgnoreChanges.ElementsAs(ctx, &defaultIgnoreChanges, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if ldapUrl == "" {
 		resp.Diagnostics.AddError(
 			"No LDAP url specified",
@@ -162,12 +344,14 @@ func (p *LDAPProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	} else {
 		conn.Debug = true
+		var startTLS *tls.Config
 		if ldapTLSUseStartTLS {
 			tflog.Debug(ctx, "Connecting using StartTLS")
 			c := tls.Config{}
 			if ldapTLSInsecureVerify {
 				c.InsecureSkipVerify = true
 			}
+			startTLS = c.Clone()
 			if err := conn.StartTLS(&c); err != nil {
 				resp.Diagnostics.AddError(
 					"Can't start TLS",
@@ -184,9 +368,31 @@ func (p *LDAPProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 			)
 			return
 		}
-		resp.DataSourceData = conn
-		resp.ResourceData = conn
+		providerData := &LDAPProviderData{
+			Conn:                 conn,
+			DefaultIgnoreChanges: defaultIgnoreChanges,
+			BackupDir:            backupDir,
+			ValidateSchema:       validateSchema,
+			BaseDN:               baseDN,
+			FollowReferrals:      followReferrals,
+			ReferralCredentials:  referralCredentials,
+			serverURL:            serverURL(ldapUrl),
+			dialOptions:          o,
+			startTLS:             startTLS,
+			bindDN:               ldapBindDN,
+			bindPassword:         ldapBindPassword,
+		}
+		resp.DataSourceData = providerData
+		resp.ResourceData = providerData
+	}
+}
+
+// serverURL returns the scheme and host of an LDAP URL.
+func serverURL(ldapUrl string) string {
+	if u, err := url.Parse(ldapUrl); err == nil {
+		return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
 	}
+	return ldapUrl
 }
 
 func (p *LDAPProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -199,6 +405,8 @@ func (p *LDAPProvider) DataSources(_ context.Context) []func() datasource.DataSo
 	return []func() datasource.DataSource{
 		NewLDAPObjectDataSource,
 		NewLDAPSearchDataSource,
+		NewLDAPSchemaDataSource,
+		NewLDAPRootDSEDataSource,
 	}
 }
 
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"net/url"
+	"strings"
+)
+
+// Searcher performs searches. It is implemented by *ldap.Conn and ReferralConn.
+type Searcher interface {
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error)
+}
+
+// maxReferralHops limits how many referrals are followed in a row to protect against referral loops.
+const maxReferralHops = 10
+
+// ReferralConn is a connection which follows the referrals and search result references returned by the server. It
+// only follows referrals using the scheme of the connection and connects to the referred servers using the same TLS
+// settings. It binds anonymously unless bindCredentials is set, which uses the bind credentials of the connection.
+type ReferralConn struct {
+	*ldap.Conn
+	// serverURL is the scheme and host of the server of the connection
+	serverURL       string
+	dialOptions     []ldap.DialOpt
+	startTLS        *tls.Config
+	bindCredentials bool
+	bindDN          string
+	bindPassword    string
+	hops            int
+}
+
+// Search performs the search request and follows the returned referrals.
+func (c *ReferralConn) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	result, err := c.Conn.Search(searchRequest)
+	return c.followReferrals(searchRequest, 0, result, err)
+}
+
+// SearchWithPaging performs the search request using the paged results control and follows the returned referrals.
+func (c *ReferralConn) SearchWithPaging(searchRequest *ldap.SearchRequest, pagingSize uint32) (*ldap.SearchResult, error) {
+	result, err := c.Conn.SearchWithPaging(searchRequest, pagingSize)
+	return c.followReferrals(searchRequest, pagingSize, result, err)
+}
+
+func (c *ReferralConn) followReferrals(searchRequest *ldap.SearchRequest, pagingSize uint32, result *ldap.SearchResult, err error) (*ldap.SearchResult, error) {
+	var urls []string
+	if result != nil {
+		urls = result.Referrals
+	}
+	continuation := true
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultReferral) {
+		// The base of the search is located on another server
+		urls = append(urls, referralURLs(err)...)
+		continuation = false
+		err = nil
+	}
+	if err != nil {
+		return result, err
+	}
+	if result == nil {
+		result = &ldap.SearchResult{}
+	}
+	result.Referrals = nil
+
+	for _, referral := range urls {
+		if c.hops >= maxReferralHops {
+			return result, ldap.NewError(ldap.LDAPResultReferralLimitExceeded, fmt.Errorf("more than %d referrals followed", maxReferralHops))
+		}
+		referredResult, err := c.followReferral(referral, searchRequest, pagingSize, continuation)
+		if err != nil {
+			return result, fmt.Errorf("following referral %s: %w", referral, err)
+		}
+		result.Entries = append(result.Entries, referredResult.Entries...)
+	}
+	return result, nil
+}
+
+// followReferral repeats the search request on the server of the referral.
+func (c *ReferralConn) followReferral(referral string, searchRequest *ldap.SearchRequest, pagingSize uint32, continuation bool) (*ldap.SearchResult, error) {
+	serverURL, request, err := referralSearchRequest(referral, c.serverURL, searchRequest, continuation)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ldap.DialURL(serverURL, c.dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if c.startTLS != nil {
+		if err := conn.StartTLS(c.startTLS.Clone()); err != nil {
+			return nil, err
+		}
+	}
+	if c.bindCredentials {
+		if err := conn.Bind(c.bindDN, c.bindPassword); err != nil {
+			return nil, err
+		}
+	}
+
+	referred := &ReferralConn{
+		Conn:            conn,
+		serverURL:       serverURL,
+		dialOptions:     c.dialOptions,
+		startTLS:        c.startTLS,
+		bindCredentials: c.bindCredentials,
+		bindDN:          c.bindDN,
+		bindPassword:    c.bindPassword,
+		hops:            c.hops + 1,
+	}
+	if pagingSize > 0 {
+		return referred.SearchWithPaging(request, pagingSize)
+	}
+	return referred.Search(request)
+}
+
+// referralSearchRequest returns the URL of the server a referral points to and the search request to send to it.
+// Referrals without a host point to the current server. Referrals changing the scheme of the current server are
+// refused, so they can't downgrade the connection from TLS to plain text. Continuation references are the references
+// returned as part of the search results instead of as its result.
+func referralSearchRequest(referral string, serverURL string, searchRequest *ldap.SearchRequest, continuation bool) (string, *ldap.SearchRequest, error) {
+	u, err := url.Parse(referral)
+	if err != nil {
+		return "", nil, err
+	}
+	current, err := url.Parse(serverURL)
+	if err != nil {
+		return "", nil, err
+	}
+	if !strings.EqualFold(u.Scheme, current.Scheme) {
+		return "", nil, fmt.Errorf("refusing to follow referral changing the scheme from %s to %s", current.Scheme, u.Scheme)
+	}
+	host := u.Host
+	if host == "" {
+		host = current.Host
+	}
+
+	request := *searchRequest
+	request.Controls = nil
+	for _, control := range searchRequest.Controls {
+		if control.GetControlType() != ldap.ControlTypePaging {
+			request.Controls = append(request.Controls, control)
+		}
+	}
+	if continuation && request.Scope == ldap.ScopeSingleLevel {
+		// references returned by a single level search point to the child entry itself
+		request.Scope = ldap.ScopeBaseObject
+	}
+	if dn := strings.TrimPrefix(u.Path, "/"); dn != "" {
+		request.BaseDN = dn
+	}
+	// RFC 4516: ldap://host/dn?attributes?scope?filter?extensions
+	if parts := strings.Split(u.RawQuery, "?"); len(parts) > 1 {
+		switch parts[1] {
+		case "base":
+			request.Scope = ldap.ScopeBaseObject
+		case "one":
+			request.Scope = ldap.ScopeSingleLevel
+		case "sub":
+			request.Scope = ldap.ScopeWholeSubtree
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			if filter, err := url.QueryUnescape(parts[2]); err == nil {
+				request.Filter = filter
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s", current.Scheme, host), &request, nil
+}
+
+// referralURLs returns the URLs of a referral result.
+func referralURLs(err error) []string {
+	var ldapError *ldap.Error
+	if !errors.As(err, &ldapError) || ldapError.Packet == nil || len(ldapError.Packet.Children) < 2 {
+		return nil
+	}
+	response := ldapError.Packet.Children[1]
+	if len(response.Children) < 4 {
+		return nil
+	}
+	var urls []string
+	for _, child := range response.Children[3].Children {
+		if v, ok := child.Value.(string); ok {
+			urls = append(urls, v)
+		} else {
+			urls = append(urls, child.Data.String())
+		}
+	}
+	return urls
+}
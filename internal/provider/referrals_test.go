@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReferralSearchRequest(t *testing.T) {
+	request := ldap.NewSearchRequest(
+		"dc=example,dc=com",
+		ldap.ScopeSingleLevel,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=person)",
+		[]string{"cn"},
+		[]ldap.Control{ldap.NewControlPaging(100), ldap.NewControlServerSideSortingWithSortKeys([]*ldap.SortKey{{AttributeType: "cn"}})},
+	)
+
+	serverURL, referred, err := referralSearchRequest("ldap://ldap2.example.com:389/ou=remote,dc=example,dc=com", "ldap://ldap.example.com", request, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ldap://ldap2.example.com:389", serverURL)
+		assert.Equal(t, "ou=remote,dc=example,dc=com", referred.BaseDN)
+		assert.Equal(t, ldap.ScopeBaseObject, referred.Scope)
+		assert.Equal(t, "(objectClass=person)", referred.Filter)
+		assert.Equal(t, []string{"cn"}, referred.Attributes)
+		if assert.Len(t, referred.Controls, 1) {
+			assert.Equal(t, ldap.ControlTypeServerSideSorting, referred.Controls[0].GetControlType())
+		}
+	}
+	assert.Len(t, request.Controls, 2)
+	assert.Equal(t, "dc=example,dc=com", request.BaseDN)
+
+	serverURL, referred, err = referralSearchRequest("ldaps://ldap2.example.com/cn=a%5C%2Cb,dc=example,dc=com??sub?(uid=a)", "ldaps://ldap.example.com", request, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ldaps://ldap2.example.com", serverURL)
+		assert.Equal(t, "cn=a\\,b,dc=example,dc=com", referred.BaseDN)
+		assert.Equal(t, ldap.ScopeWholeSubtree, referred.Scope)
+		assert.Equal(t, "(uid=a)", referred.Filter)
+	}
+
+	_, referred, err = referralSearchRequest("ldap://ldap2.example.com", "ldap://ldap.example.com", request, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "dc=example,dc=com", referred.BaseDN)
+		assert.Equal(t, ldap.ScopeSingleLevel, referred.Scope)
+	}
+
+	// referrals without a host point to the current server
+	serverURL, referred, err = referralSearchRequest("ldap:///ou=remote,dc=example,dc=com", "ldap://ldap.example.com:1389", request, false)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ldap://ldap.example.com:1389", serverURL)
+		assert.Equal(t, "ou=remote,dc=example,dc=com", referred.BaseDN)
+	}
+
+	// referrals must not change the scheme
+	_, _, err = referralSearchRequest("ldap://ldap2.example.com/ou=remote,dc=example,dc=com", "ldaps://ldap.example.com", request, false)
+	assert.Error(t, err)
+}
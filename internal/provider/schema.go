@@ -0,0 +1,319 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"strings"
+)
+
+// ObjectClass is an object class definition of the subschema of the server (RFC 4512 section 4.1.1).
+type ObjectClass struct {
+	OID         string
+	Names       []string
+	Description string
+	Superiors   []string
+	// Kind is one of ABSTRACT, STRUCTURAL or AUXILIARY
+	Kind string
+	Must []string
+	May  []string
+}
+
+// AttributeType is an attribute type definition of the subschema of the server (RFC 4512 section 4.1.2).
+type AttributeType struct {
+	OID                string
+	Names              []string
+	Description        string
+	Superior           string
+	Equality           string
+	Ordering           string
+	Substring          string
+	Syntax             string
+	SingleValue        bool
+	NoUserModification bool
+	Usage              string
+}
+
+// Schema holds the object classes and attribute types of the subschema of the server.
+type Schema struct {
+	// DN is the DN of the subschema subentry the schema was read from
+	DN             string
+	ObjectClasses  []*ObjectClass
+	AttributeTypes []*AttributeType
+
+	objectClassesByName  map[string]*ObjectClass
+	attributeTypesByName map[string]*AttributeType
+}
+
+// GetSchema reads the subschema subentry announced in the root DSE of the server.
+func GetSchema(conn *ldap.Conn) (*Schema, error) {
+	subschemaSubentry := "cn=Subschema"
+	if rootDSE, err := GetEntry(conn, "", "subschemaSubentry"); err == nil {
+		if v := rootDSE.GetAttributeValue("subschemaSubentry"); v != "" {
+			subschemaSubentry = v
+		}
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		subschemaSubentry,
+		ldap.ScopeBaseObject,
+		ldap.NeverDerefAliases,
+		0,
+		0,
+		false,
+		"(objectClass=*)",
+		[]string{"objectClasses", "attributeTypes"},
+		[]ldap.Control{},
+	))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) == 0 {
+		return nil, fmt.Errorf("subschema subentry %s not found", subschemaSubentry)
+	}
+	s, err := ParseSchema(
+		result.Entries[0].GetAttributeValues("objectClasses"),
+		result.Entries[0].GetAttributeValues("attributeTypes"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.DN = result.Entries[0].DN
+	return s, nil
+}
+
+// ParseSchema parses the values of the objectClasses and attributeTypes attributes of a subschema subentry.
+func ParseSchema(objectClasses []string, attributeTypes []string) (*Schema, error) {
+	s := &Schema{
+		objectClassesByName:  map[string]*ObjectClass{},
+		attributeTypesByName: map[string]*AttributeType{},
+	}
+	for _, definition := range objectClasses {
+		fields, err := parseSchemaDefinition(definition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid object class %q: %w", definition, err)
+		}
+		c := &ObjectClass{
+			OID:         fields.oid,
+			Names:       fields.values["NAME"],
+			Description: fields.value("DESC"),
+			Superiors:   fields.values["SUP"],
+			Kind:        "STRUCTURAL",
+			Must:        fields.values["MUST"],
+			May:         fields.values["MAY"],
+		}
+		for _, kind := range []string{"ABSTRACT", "AUXILIARY"} {
+			if fields.flags[kind] {
+				c.Kind = kind
+			}
+		}
+		s.ObjectClasses = append(s.ObjectClasses, c)
+		s.objectClassesByName[strings.ToLower(c.OID)] = c
+		for _, name := range c.Names {
+			s.objectClassesByName[strings.ToLower(name)] = c
+		}
+	}
+	for _, definition := range attributeTypes {
+		fields, err := parseSchemaDefinition(definition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute type %q: %w", definition, err)
+		}
+		a := &AttributeType{
+			OID:                fields.oid,
+			Names:              fields.values["NAME"],
+			Description:        fields.value("DESC"),
+			Superior:           fields.value("SUP"),
+			Equality:           fields.value("EQUALITY"),
+			Ordering:           fields.value("ORDERING"),
+			Substring:          fields.value("SUBSTR"),
+			Syntax:             fields.value("SYNTAX"),
+			SingleValue:        fields.flags["SINGLE-VALUE"],
+			NoUserModification: fields.flags["NO-USER-MODIFICATION"],
+			Usage:              fields.value("USAGE"),
+		}
+		if a.Usage == "" {
+			a.Usage = "userApplications"
+		}
+		s.AttributeTypes = append(s.AttributeTypes, a)
+		s.attributeTypesByName[strings.ToLower(a.OID)] = a
+		for _, name := range a.Names {
+			s.attributeTypesByName[strings.ToLower(name)] = a
+		}
+	}
+	// Inherit the rules of the superior attribute type if the definition doesn't contain them
+	for _, a := range s.AttributeTypes {
+		superior := s.AttributeType(a.Superior)
+		for depth := 0; superior != nil && depth < len(s.AttributeTypes); depth++ {
+			if a.Syntax == "" {
+				a.Syntax = superior.Syntax
+			}
+			if a.Equality == "" {
+				a.Equality = superior.Equality
+			}
+			if a.Ordering == "" {
+				a.Ordering = superior.Ordering
+			}
+			if a.Substring == "" {
+				a.Substring = superior.Substring
+			}
+			superior = s.AttributeType(superior.Superior)
+		}
+	}
+	return s, nil
+}
+
+// ObjectClass returns the object class with the given name or OID or nil if the schema doesn't define it.
+func (s *Schema) ObjectClass(name string) *ObjectClass {
+	return s.objectClassesByName[strings.ToLower(name)]
+}
+
+// AttributeType returns the attribute type with the given name or OID or nil if the schema doesn't define it.
+// Attribute options like ";binary" are ignored.
+func (s *Schema) AttributeType(name string) *AttributeType {
+	name, _, _ = strings.Cut(name, ";")
+	return s.attributeTypesByName[strings.ToLower(name)]
+}
+
+// Name returns the first name of the object class or its OID if it has no name.
+func (c *ObjectClass) Name() string {
+	if len(c.Names) > 0 {
+		return c.Names[0]
+	}
+	return c.OID
+}
+
+// Name returns the first name of the attribute type or its OID if it has no name.
+func (a *AttributeType) Name() string {
+	if len(a.Names) > 0 {
+		return a.Names[0]
+	}
+	return a.OID
+}
+
+// IsSubtype returns whether the attribute type a is the attribute type b or derived from it.
+func (s *Schema) IsSubtype(a *AttributeType, b *AttributeType) bool {
+	for depth := 0; a != nil && depth < len(s.AttributeTypes); depth++ {
+		if a == b {
+			return true
+		}
+		a = s.AttributeType(a.Superior)
+	}
+	return false
+}
+
+// ClassAttributeTypes returns the required and allowed attribute types of the given object classes including the
+// attribute types of their superior classes.
+func (s *Schema) ClassAttributeTypes(classes []*ObjectClass) (must []*AttributeType, may []*AttributeType) {
+	seen := map[*ObjectClass]bool{}
+	var collect func(c *ObjectClass)
+	collect = func(c *ObjectClass) {
+		if c == nil || seen[c] {
+			return
+		}
+		seen[c] = true
+		for _, name := range c.Must {
+			if a := s.AttributeType(name); a != nil {
+				must = append(must, a)
+			}
+		}
+		for _, name := range c.May {
+			if a := s.AttributeType(name); a != nil {
+				may = append(may, a)
+			}
+		}
+		for _, superior := range c.Superiors {
+			collect(s.ObjectClass(superior))
+		}
+	}
+	for _, c := range classes {
+		collect(c)
+	}
+	return must, may
+}
+
+// schemaDefinition holds the fields of a parsed schema definition.
+type schemaDefinition struct {
+	oid    string
+	values map[string][]string
+	flags  map[string]bool
+}
+
+func (d schemaDefinition) value(keyword string) string {
+	if values := d.values[keyword]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// schemaFlags are the keywords of schema definitions without a value.
+var schemaFlags = []string{
+	"OBSOLETE", "SINGLE-VALUE", "COLLECTIVE", "NO-USER-MODIFICATION", "ABSTRACT", "STRUCTURAL", "AUXILIARY",
+}
+
+// parseSchemaDefinition parses a definition like ( 2.5.6.6 NAME 'person' SUP top STRUCTURAL MUST ( sn $ cn ) ).
+func parseSchemaDefinition(definition string) (schemaDefinition, error) {
+	d := schemaDefinition{values: map[string][]string{}, flags: map[string]bool{}}
+	tokens, err := tokenizeSchemaDefinition(definition)
+	if err != nil {
+		return d, err
+	}
+	if len(tokens) < 3 || tokens[0] != "(" || tokens[len(tokens)-1] != ")" {
+		return d, fmt.Errorf("definition isn't enclosed in parentheses")
+	}
+	tokens = tokens[1 : len(tokens)-1]
+	d.oid = strings.Trim(tokens[0], "'")
+	for i := 1; i < len(tokens); i++ {
+		keyword := strings.ToUpper(tokens[i])
+		if ContainsAttributeType(schemaFlags, keyword) {
+			d.flags[keyword] = true
+			continue
+		}
+		if i+1 >= len(tokens) {
+			return d, fmt.Errorf("missing value of %s", keyword)
+		}
+		i++
+		if tokens[i] != "(" {
+			d.values[keyword] = []string{strings.Trim(tokens[i], "'")}
+			continue
+		}
+		var values []string
+		for i++; i < len(tokens) && tokens[i] != ")"; i++ {
+			if tokens[i] != "$" {
+				values = append(values, strings.Trim(tokens[i], "'"))
+			}
+		}
+		if i >= len(tokens) {
+			return d, fmt.Errorf("unterminated value list of %s", keyword)
+		}
+		d.values[keyword] = values
+	}
+	return d, nil
+}
+
+// tokenizeSchemaDefinition splits a schema definition into parentheses, dollar signs, quoted strings (keeping
+// their quotes) and words.
+func tokenizeSchemaDefinition(definition string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(definition); {
+		switch c := definition[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '$':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '\'':
+			end := strings.IndexByte(definition[i+1:], '\'')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated quoted string at position %d", i)
+			}
+			tokens = append(tokens, definition[i:i+end+2])
+			i += end + 2
+		default:
+			end := strings.IndexAny(definition[i:], " \t\n\r()$'")
+			if end == -1 {
+				end = len(definition) - i
+			}
+			tokens = append(tokens, definition[i:i+end])
+			i += end
+		}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseSchema(t *testing.T) {
+	s, err := ParseSchema(
+		[]string{
+			"( 2.5.6.0 NAME 'top' DESC 'top of the superclass chain' ABSTRACT MUST objectClass )",
+			"( 2.5.6.6 NAME 'person' DESC 'RFC2256: a person' SUP top STRUCTURAL MUST ( sn $ cn ) " +
+				"MAY ( userPassword $ telephoneNumber $ seeAlso $ description ) )",
+			"( 1.3.6.1.1.3.1 NAME 'uidObject' DESC 'RFC2377: uid object' SUP top AUXILIARY MUST uid )",
+		},
+		[]string{
+			"( 2.5.4.0 NAME 'objectClass' EQUALITY objectIdentifierMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 )",
+			"( 2.5.4.41 NAME 'name' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch " +
+				"SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32768} )",
+			"( 2.5.4.3 NAME ( 'cn' 'commonName' ) DESC 'RFC4519: common name(s) for which the entity is known by' SUP name )",
+			"( 2.5.4.4 NAME ( 'sn' 'surname' ) SUP name )",
+			"( 0.9.2342.19200300.100.1.1 NAME ( 'uid' 'userid' ) EQUALITY caseIgnoreMatch " +
+				"SYNTAX '1.3.6.1.4.1.1466.115.121.1.15' SINGLE-VALUE )",
+			"( 1.3.6.1.1.16.4 NAME 'entryUUID' EQUALITY UUIDMatch SYNTAX 1.3.6.1.1.16.1 SINGLE-VALUE " +
+				"NO-USER-MODIFICATION USAGE directoryOperation )",
+		},
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Len(t, s.ObjectClasses, 3)
+	assert.Len(t, s.AttributeTypes, 6)
+
+	person := s.ObjectClass("PERSON")
+	if assert.NotNil(t, person) {
+		assert.Equal(t, "2.5.6.6", person.OID)
+		assert.Equal(t, "RFC2256: a person", person.Description)
+		assert.Equal(t, []string{"top"}, person.Superiors)
+		assert.Equal(t, "STRUCTURAL", person.Kind)
+		assert.Equal(t, []string{"sn", "cn"}, person.Must)
+		assert.Equal(t, []string{"userPassword", "telephoneNumber", "seeAlso", "description"}, person.May)
+	}
+	assert.Equal(t, "AUXILIARY", s.ObjectClass("uidObject").Kind)
+	assert.Equal(t, "ABSTRACT", s.ObjectClass("2.5.6.0").Kind)
+	assert.Nil(t, s.ObjectClass("inetOrgPerson"))
+
+	cn := s.AttributeType("commonName")
+	if assert.NotNil(t, cn) {
+		assert.Same(t, cn, s.AttributeType("cn"))
+		assert.Equal(t, []string{"cn", "commonName"}, cn.Names)
+		assert.Equal(t, "name", cn.Superior)
+		assert.Equal(t, "1.3.6.1.4.1.1466.115.121.1.15{32768}", cn.Syntax)
+		assert.Equal(t, "caseIgnoreMatch", cn.Equality)
+		assert.False(t, cn.SingleValue)
+		assert.True(t, s.IsSubtype(cn, s.AttributeType("name")))
+		assert.False(t, s.IsSubtype(s.AttributeType("name"), cn))
+	}
+	assert.Same(t, s.AttributeType("uid"), s.AttributeType("uid;binary"))
+	assert.Equal(t, "1.3.6.1.4.1.1466.115.121.1.15", s.AttributeType("uid").Syntax)
+	assert.True(t, s.AttributeType("uid").SingleValue)
+	assert.True(t, s.AttributeType("entryUUID").NoUserModification)
+	assert.Equal(t, "directoryOperation", s.AttributeType("entryUUID").Usage)
+	assert.Equal(t, "userApplications", s.AttributeType("sn").Usage)
+
+	must, may := s.ClassAttributeTypes([]*ObjectClass{person, s.ObjectClass("uidObject")})
+	assert.ElementsMatch(t, []*AttributeType{s.AttributeType("sn"), cn, s.AttributeType("objectClass"), s.AttributeType("uid")}, must)
+	assert.Len(t, may, 0)
+}
+
+func TestParseSchemaInvalid(t *testing.T) {
+	for _, definition := range []string{
+		"2.5.6.0 NAME 'top'",
+		"( 2.5.6.0 NAME 'top )",
+		"( 2.5.6.6 NAME 'person' MUST ( sn $ cn )",
+		"( 2.5.6.6 NAME )",
+	} {
+		_, err := ParseSchema([]string{definition}, nil)
+		assert.Error(t, err, definition)
+	}
+}
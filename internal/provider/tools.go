@@ -2,16 +2,33 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"github.com/go-ldap/ldap/v3"
 	"github.com/go-ldap/ldif"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/thoas/go-funk"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // GetEntry returns a specific entry and is a shortcut around the search function.
-func GetEntry(conn *ldap.Conn, dn string, attrs ...string) (ldap.Entry, error) {
-	s := ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, 0, 0, 0, false, "(&)", attrs, []ldap.Control{})
+func GetEntry(conn Searcher, dn string, attrs ...string) (ldap.Entry, error) {
+	return GetEntryWithDerefAliases(conn, dn, ldap.NeverDerefAliases, attrs...)
+}
+
+// GetEntryWithDerefAliases returns a specific entry, dereferencing aliases as specified.
+func GetEntryWithDerefAliases(conn Searcher, dn string, derefAliases int, attrs ...string) (ldap.Entry, error) {
+	s := ldap.NewSearchRequest(dn, ldap.ScopeBaseObject, derefAliases, 0, 0, false, "(&)", attrs, []ldap.Control{})
 
 	if result, err := conn.Search(s); err != nil {
 		return ldap.Entry{}, err
@@ -19,10 +36,317 @@ func GetEntry(conn *ldap.Conn, dn string, attrs ...string) (ldap.Entry, error) {
 		if len(result.Entries) != 1 {
 			return ldap.Entry{}, fmt.Errorf("search returned %d results", len(result.Entries))
 		}
+		if err := ResolveRangedAttributes(conn, result.Entries[0]); err != nil {
+			return ldap.Entry{}, err
+		}
 		return *result.Entries[0], nil
 	}
 }
 
+// rangeOption matches the range option servers like Active Directory use to return large multi-valued attributes in
+// parts, e.g. member;range=0-1499.
+var rangeOption = regexp.MustCompile(`(?i);range=(\d+)-(\d+|\*)`)
+
+// RangedAttributeType splits an attribute description with a range option into the description without the option
+// and the last index of the range, which is -1 if the range includes the last value. ok is false if the description
+// has no range option.
+func RangedAttributeType(name string) (attributeType string, end int, ok bool) {
+	match := rangeOption.FindStringSubmatchIndex(name)
+	if match == nil {
+		return name, 0, false
+	}
+	attributeType = name[:match[0]] + name[match[1]:]
+	last := name[match[4]:match[5]]
+	if last == "*" {
+		return attributeType, -1, true
+	}
+	end, err := strconv.Atoi(last)
+	if err != nil {
+		return name, 0, false
+	}
+	return attributeType, end, true
+}
+
+// ResolveRangedAttributes fetches the remaining values of the attributes of the entry the server only returned a
+// range of and merges them into one attribute without the range option.
+func ResolveRangedAttributes(conn Searcher, entry *ldap.Entry) error {
+	for _, attribute := range entry.Attributes {
+		attributeType, end, ok := RangedAttributeType(attribute.Name)
+		if !ok {
+			continue
+		}
+		attribute.Name = attributeType
+		for end >= 0 {
+			s := ldap.NewSearchRequest(entry.DN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 0, 0, false, "(&)",
+				[]string{fmt.Sprintf("%s;range=%d-*", attributeType, end+1)}, []ldap.Control{})
+			result, err := conn.Search(s)
+			if err != nil {
+				return fmt.Errorf("fetching values of %s from index %d: %w", attributeType, end+1, err)
+			}
+			if len(result.Entries) != 1 {
+				return fmt.Errorf("fetching values of %s from index %d: search returned %d results", attributeType, end+1, len(result.Entries))
+			}
+			var next *ldap.EntryAttribute
+			nextEnd := -1
+			for _, a := range result.Entries[0].Attributes {
+				if t, e, ok := RangedAttributeType(a.Name); ok && strings.EqualFold(t, attributeType) {
+					next, nextEnd = a, e
+					break
+				}
+			}
+			if next == nil {
+				break
+			}
+			attribute.Values = append(attribute.Values, next.Values...)
+			attribute.ByteValues = append(attribute.ByteValues, next.ByteValues...)
+			if nextEnd >= 0 && nextEnd <= end {
+				return fmt.Errorf("fetching values of %s from index %d: server returned the range up to %d", attributeType, end+1, nextEnd)
+			}
+			end = nextEnd
+		}
+	}
+	return nil
+}
+
+// UUIDAttributeTypes are the operational attributes different servers use for the stable identifier of an entry.
+var UUIDAttributeTypes = []string{"entryUUID", "nsUniqueId", "objectGUID"}
+
+// EntryUUID returns the stable identifier of an entry or an empty string if the entry doesn't include one.
+// Binary objectGUIDs are converted into their string representation.
+func EntryUUID(entry ldap.Entry) string {
+	if uuid := entry.GetEqualFoldAttributeValue("entryUUID"); uuid != "" {
+		return uuid
+	}
+	if uuid := entry.GetEqualFoldAttributeValue("nsUniqueId"); uuid != "" {
+		return uuid
+	}
+	if guid := entry.GetEqualFoldRawAttributeValue("objectGUID"); len(guid) == 16 {
+		return fmt.Sprintf(
+			"%08x-%04x-%04x-%x-%x",
+			binary.LittleEndian.Uint32(guid[0:4]),
+			binary.LittleEndian.Uint16(guid[4:6]),
+			binary.LittleEndian.Uint16(guid[6:8]),
+			guid[8:10],
+			guid[10:16],
+		)
+	}
+	return ""
+}
+
+// UUIDFilter returns a search filter matching the entry with the given stable identifier.
+func UUIDFilter(uuid string) string {
+	escapedUUID := ldap.EscapeFilter(uuid)
+	filter := fmt.Sprintf("(entryUUID=%s)(nsUniqueId=%s)", escapedUUID, escapedUUID)
+	if guid, err := hex.DecodeString(strings.ReplaceAll(uuid, "-", "")); err == nil && len(guid) == 16 {
+		// objectGUIDs are stored in mixed endianness
+		binary.BigEndian.PutUint32(guid[0:4], binary.LittleEndian.Uint32(guid[0:4]))
+		binary.BigEndian.PutUint16(guid[4:6], binary.LittleEndian.Uint16(guid[4:6]))
+		binary.BigEndian.PutUint16(guid[6:8], binary.LittleEndian.Uint16(guid[6:8]))
+		var escapedGUID strings.Builder
+		for _, b := range guid {
+			escapedGUID.WriteString(fmt.Sprintf("\\%02x", b))
+		}
+		filter += fmt.Sprintf("(objectGUID=%s)", escapedGUID.String())
+	}
+	return fmt.Sprintf("(|%s)", filter)
+}
+
+// FindEntryByUUID searches the naming contexts of the server for the entry with the given stable identifier.
+func FindEntryByUUID(conn Searcher, uuid string, attrs ...string) (ldap.Entry, error) {
+	rootDSE, err := GetEntry(conn, "", "namingContexts")
+	if err != nil {
+		return ldap.Entry{}, err
+	}
+	for _, namingContext := range rootDSE.GetAttributeValues("namingContexts") {
+		s := ldap.NewSearchRequest(namingContext, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false, UUIDFilter(uuid), attrs, []ldap.Control{})
+		result, err := conn.Search(s)
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return ldap.Entry{}, err
+		}
+		if len(result.Entries) == 1 {
+			return *result.Entries[0], nil
+		}
+	}
+	return ldap.Entry{}, ldap.NewError(ldap.LDAPResultNoSuchObject, fmt.Errorf("no entry with the UUID %s found", uuid))
+}
+
+// SupportsControl checks whether the server advertises support for the given control in its root DSE.
+func SupportsControl(conn Searcher, controlType string) bool {
+	if rootDSE, err := GetEntry(conn, "", "supportedControl"); err == nil {
+		return funk.ContainsString(rootDSE.GetAttributeValues("supportedControl"), controlType)
+	}
+	return false
+}
+
+// BuildDN joins an RDN of the form type=value and a parent DN. The value of the RDN is escaped.
+func BuildDN(rdn string, parentDN string) (string, error) {
+	attributeType, value, found := strings.Cut(rdn, "=")
+	attributeType = strings.TrimSpace(attributeType)
+	value = strings.TrimSpace(value)
+	if !found || attributeType == "" || value == "" {
+		return "", fmt.Errorf("the RDN %q doesn't have the form type=value", rdn)
+	}
+	dn := fmt.Sprintf("%s=%s", attributeType, ldap.EscapeDN(value))
+	if parentDN != "" {
+		dn = fmt.Sprintf("%s,%s", dn, parentDN)
+	}
+	return dn, nil
+}
+
+// RequestedAttributeTypes returns the attribute types a data source requests: the requested attribute types or all
+// user attributes if they aren't set, together with the additional attribute types.
+func RequestedAttributeTypes(ctx context.Context, requested types.Set, additional []string, diagnostics *diag.Diagnostics) []string {
+	if requested.IsNull() || requested.IsUnknown() {
+		return append(additional, "*")
+	}
+	var attributeTypes []string
+	diagnostics.Append(requested.ElementsAs(ctx, &attributeTypes, false)...)
+	if len(additional) > 0 {
+		// 1.1 requests no attributes and must not be combined with other attribute types
+		attributeTypes = funk.FilterString(attributeTypes, func(attributeType string) bool {
+			return attributeType != "1.1"
+		})
+	}
+	return append(attributeTypes, additional...)
+}
+
+// CompareDN compares two DNs hierarchically from their last RDN on, so parent entries are sorted before their
+// children. The comparison ignores case.
+func CompareDN(a string, b string) int {
+	rdnsA := dnComponents(a)
+	rdnsB := dnComponents(b)
+	for i := 0; i < len(rdnsA) && i < len(rdnsB); i++ {
+		if c := strings.Compare(rdnsA[len(rdnsA)-1-i], rdnsB[len(rdnsB)-1-i]); c != 0 {
+			return c
+		}
+	}
+	return len(rdnsA) - len(rdnsB)
+}
+
+// dnComponents returns the lowercased RDNs of a DN.
+func dnComponents(dn string) []string {
+	var rdns []string
+	if parsedDN, err := ldap.ParseDN(dn); err == nil {
+		for _, rdn := range parsedDN.RDNs {
+			rdns = append(rdns, strings.ToLower(rdn.String()))
+		}
+	} else {
+		rdns = strings.Split(strings.ToLower(dn), ",")
+	}
+	return rdns
+}
+
+// SortEntries sorts entries by their DN and then by the first value of the attribute types of the sort keys. Entries
+// without a value for a sort key are sorted after the other entries. Values are compared case-insensitively, matching
+// rules aren't supported.
+func SortEntries(entries []*ldap.Entry, sortKeys []*ldap.SortKey) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return CompareDN(entries[i].DN, entries[j].DN) < 0
+	})
+	sort.SliceStable(entries, func(i, j int) bool {
+		for _, key := range sortKeys {
+			a := entries[i].GetEqualFoldAttributeValues(key.AttributeType)
+			b := entries[j].GetEqualFoldAttributeValues(key.AttributeType)
+			if len(a) == 0 || len(b) == 0 {
+				if len(a) != len(b) {
+					return len(b) == 0
+				}
+				continue
+			}
+			c := strings.Compare(strings.ToLower(a[0]), strings.ToLower(b[0]))
+			if key.Reverse {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// AttributeDescriptionPattern matches an attribute description of RFC 4512: a name or a numeric OID followed by
+// options, e.g. cn, 2.5.4.3 or userCertificate;binary.
+var AttributeDescriptionPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*|(0|[1-9][0-9]*)(\.(0|[1-9][0-9]*))+)(;[A-Za-z0-9-]+)*$`)
+
+// FilterMatch describes a comparison of an attribute in a search filter.
+type FilterMatch struct {
+	Attribute string
+	// Operator is one of equality, presence, substring, starts_with, ends_with, greater_or_equal or less_or_equal
+	Operator string
+	Value    string
+	Not      bool
+}
+
+// Filter returns the escaped search filter of the comparison. The attribute must be a valid attribute description.
+func (m FilterMatch) Filter() (string, error) {
+	if !AttributeDescriptionPattern.MatchString(m.Attribute) {
+		return "", fmt.Errorf("invalid attribute description %q", m.Attribute)
+	}
+	var filter string
+	switch m.Operator {
+	case "presence":
+		filter = fmt.Sprintf("(%s=*)", m.Attribute)
+	case "substring":
+		filter = fmt.Sprintf("(%s=*%s*)", m.Attribute, ldap.EscapeFilter(m.Value))
+	case "starts_with":
+		filter = fmt.Sprintf("(%s=%s*)", m.Attribute, ldap.EscapeFilter(m.Value))
+	case "ends_with":
+		filter = fmt.Sprintf("(%s=*%s)", m.Attribute, ldap.EscapeFilter(m.Value))
+	case "greater_or_equal":
+		filter = fmt.Sprintf("(%s>=%s)", m.Attribute, ldap.EscapeFilter(m.Value))
+	case "less_or_equal":
+		filter = fmt.Sprintf("(%s<=%s)", m.Attribute, ldap.EscapeFilter(m.Value))
+	default:
+		filter = fmt.Sprintf("(%s=%s)", m.Attribute, ldap.EscapeFilter(m.Value))
+	}
+	if m.Not {
+		filter = fmt.Sprintf("(!%s)", filter)
+	}
+	return filter, nil
+}
+
+// CombineFilters joins filters with the given mode "and" or "or". A single filter is returned unchanged.
+func CombineFilters(mode string, filters ...string) string {
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	operator := "&"
+	if mode == "or" {
+		operator = "|"
+	}
+	return fmt.Sprintf("(%s%s)", operator, strings.Join(filters, ""))
+}
+
+// DerefAliases maps the values of the deref_aliases options to the alias dereferencing modes of a search.
+var DerefAliases = map[string]int{
+	"never":     ldap.NeverDerefAliases,
+	"searching": ldap.DerefInSearching,
+	"finding":   ldap.DerefFindingBaseObj,
+	"always":    ldap.DerefAlways,
+}
+
+// SameDN returns whether the given DNs denote the same entry, ignoring case and formatting differences.
+func SameDN(a string, b string) bool {
+	parsedA, errA := ldap.ParseDN(a)
+	parsedB, errB := ldap.ParseDN(b)
+	if errA != nil || errB != nil {
+		return strings.EqualFold(a, b)
+	}
+	return parsedA.EqualFold(parsedB)
+}
+
+// DNDepth returns the number of RDNs in the given DN.
+func DNDepth(dn string) int {
+	if parsedDN, err := ldap.ParseDN(dn); err == nil {
+		return len(parsedDN.RDNs)
+	}
+	return strings.Count(dn, ",") + 1
+}
+
 // ToLDIF converts the given ldap entry into an LDIF representation.
 func ToLDIF(entry interface{}) string {
 	if l, err := ldif.ToLDIF(entry); err == nil {
@@ -45,6 +369,16 @@ func MaskAttributes(ctx context.Context, attributes map[string][]string) context
 	return ctx
 }
 
+// MaskAllAttributes masks all values of the given attributes, e.g. because they are sensitive as a whole.
+func MaskAllAttributes(ctx context.Context, attributes map[string][]string) context.Context {
+	for _, values := range attributes {
+		funk.ForEach(values, func(value string) {
+			ctx = tflog.MaskLogStrings(ctx, value)
+		})
+	}
+	return ctx
+}
+
 // MaskAttributesFromArray is a MaskAttributes adapter for ldap.EntryAttribute-Arrays.
 func MaskAttributesFromArray(ctx context.Context, attributes []*ldap.EntryAttribute) context.Context {
 	var attributesHash = funk.Reduce(
@@ -61,3 +395,46 @@ func MaskAttributesFromArray(ctx context.Context, attributes []*ldap.EntryAttrib
 		return MaskAttributes(ctx, h)
 	}
 }
+
+// MatchAttributeType checks whether an attribute type matches the given pattern. Patterns enclosed in slashes are
+// regular expressions which have to match the whole type, other patterns are matched as globs. As attribute types in
+// LDAP, the patterns are case-insensitive.
+func MatchAttributeType(pattern string, attributeType string) (bool, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		r, err := regexp.Compile(fmt.Sprintf("(?i)^(?:%s)$", pattern[1:len(pattern)-1]))
+		if err != nil {
+			return false, err
+		}
+		return r.MatchString(attributeType), nil
+	}
+	return path.Match(strings.ToLower(pattern), strings.ToLower(attributeType))
+}
+
+// ContainsAttributeType checks whether the given list contains an attribute type, ignoring the case.
+func ContainsAttributeType(attributeTypes []string, attributeType string) bool {
+	for _, t := range attributeTypes {
+		if strings.EqualFold(t, attributeType) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFullyKnown checks whether the value and all values nested in it are known.
+func IsFullyKnown(ctx context.Context, value attr.Value) bool {
+	v, err := value.ToTerraformValue(ctx)
+	return err == nil && v.IsFullyKnown()
+}
+
+// PrintableValues returns the values of an attribute with binary values (like an objectGUID) being base64 encoded.
+func PrintableValues(attribute *ldap.EntryAttribute) []string {
+	values := make([]string, len(attribute.Values))
+	for i, value := range attribute.Values {
+		if utf8.ValidString(value) {
+			values[i] = value
+		} else {
+			values[i] = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+	}
+	return values
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatchAttributeType(t *testing.T) {
+	tests := []struct {
+		pattern       string
+		attributeType string
+		matches       bool
+	}{
+		{"userPassword", "userPassword", true},
+		{"userpassword", "userPassword", true},
+		{"userPassword", "userPasswordHistory", false},
+		{"samba*", "sambaSID", true},
+		{"samba*", "SambaNTPassword", true},
+		{"samba*", "uid", false},
+		{"/x-acme-.*/", "x-acme-costCenter", true},
+		{"/x-acme-.*/", "my-x-acme-costCenter", false},
+		{"/mail|uid/", "uid", true},
+		{"/mail|uid/", "mailAlias", false},
+	}
+	for _, test := range tests {
+		matches, err := MatchAttributeType(test.pattern, test.attributeType)
+		assert.NoError(t, err, test.pattern)
+		assert.Equal(t, test.matches, matches, "%s matching %s", test.pattern, test.attributeType)
+	}
+
+	_, err := MatchAttributeType("[samba", "sambaSID")
+	assert.Error(t, err)
+	_, err = MatchAttributeType("/(samba/", "sambaSID")
+	assert.Error(t, err)
+}
+
+func TestEntryUUID(t *testing.T) {
+	entry := ldap.NewEntry("cn=test,dc=example,dc=com", map[string][]string{
+		"objectGUID": {string([]byte{0xe4, 0xd9, 0xa6, 0xc2, 0x1b, 0x5f, 0x3c, 0x4a, 0x8e, 0x2d, 0x1f, 0x2a, 0x3b, 0x4c, 0x5d, 0x6e})},
+	})
+	entry.Attributes[0].ByteValues = [][]byte{[]byte(entry.Attributes[0].Values[0])}
+	assert.Equal(t, "c2a6d9e4-5f1b-4a3c-8e2d-1f2a3b4c5d6e", EntryUUID(*entry))
+
+	entry = ldap.NewEntry("cn=test,dc=example,dc=com", map[string][]string{
+		"entryUUID": {"0d5e8f2a-6c1b-103e-8a7f-d1c2b3a4f5e6"},
+	})
+	assert.Equal(t, "0d5e8f2a-6c1b-103e-8a7f-d1c2b3a4f5e6", EntryUUID(*entry))
+}
+
+func TestUUIDFilter(t *testing.T) {
+	assert.Equal(
+		t,
+		`(|(entryUUID=c2a6d9e4-5f1b-4a3c-8e2d-1f2a3b4c5d6e)(nsUniqueId=c2a6d9e4-5f1b-4a3c-8e2d-1f2a3b4c5d6e)(objectGUID=\e4\d9\a6\c2\1b\5f\3c\4a\8e\2d\1f\2a\3b\4c\5d\6e))`,
+		UUIDFilter("c2a6d9e4-5f1b-4a3c-8e2d-1f2a3b4c5d6e"),
+	)
+	assert.Equal(t, `(|(entryUUID=\2a)(nsUniqueId=\2a))`, UUIDFilter("*"))
+}
+
+func TestBuildDN(t *testing.T) {
+	tests := []struct {
+		rdn      string
+		parentDN string
+		dn       string
+	}{
+		{"cn=test", "dc=example,dc=com", "cn=test,dc=example,dc=com"},
+		{"cn=Smith, John", "ou=people,dc=example,dc=com", "cn=Smith\\, John,ou=people,dc=example,dc=com"},
+		{"cn=a+b=c", "dc=example,dc=com", "cn=a\\+b=c,dc=example,dc=com"},
+		{" uid = alice", "", "uid=alice"},
+	}
+	for _, test := range tests {
+		dn, err := BuildDN(test.rdn, test.parentDN)
+		assert.NoError(t, err, test.rdn)
+		assert.Equal(t, test.dn, dn, test.rdn)
+	}
+	for _, rdn := range []string{"test", "=test", "cn="} {
+		_, err := BuildDN(rdn, "dc=example,dc=com")
+		assert.Error(t, err, rdn)
+	}
+}
+
+func TestRequestedAttributeTypes(t *testing.T) {
+	ctx := context.Background()
+	var diagnostics diag.Diagnostics
+	set := func(values ...string) types.Set {
+		v, _ := types.SetValueFrom(ctx, types.StringType, values)
+		return v
+	}
+	assert.Equal(t, []string{"creatorsName", "*"}, RequestedAttributeTypes(ctx, types.SetNull(types.StringType), []string{"creatorsName"}, &diagnostics))
+	assert.Equal(t, []string{"cn", "mail"}, RequestedAttributeTypes(ctx, set("cn", "mail"), nil, &diagnostics))
+	assert.Equal(t, []string{"1.1"}, RequestedAttributeTypes(ctx, set("1.1"), nil, &diagnostics))
+	assert.Equal(t, []string{"creatorsName"}, RequestedAttributeTypes(ctx, set("1.1"), []string{"creatorsName"}, &diagnostics))
+	assert.False(t, diagnostics.HasError())
+}
+
+func TestIsFullyKnown(t *testing.T) {
+	ctx := context.Background()
+	listType := types.ListType{ElemType: types.StringType}
+	assert.True(t, IsFullyKnown(ctx, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("person")})))
+	assert.True(t, IsFullyKnown(ctx, types.MapNull(listType)))
+	assert.False(t, IsFullyKnown(ctx, types.ListUnknown(types.StringType)))
+	assert.False(t, IsFullyKnown(ctx, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("person"), types.StringUnknown()})))
+	assert.False(t, IsFullyKnown(ctx, types.MapValueMust(listType, map[string]attr.Value{
+		"cn": types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}),
+	})))
+}
+
+func TestCompareDN(t *testing.T) {
+	assert.Equal(t, 0, CompareDN("cn=Test,dc=example,dc=com", "CN=test, dc=Example,dc=com"))
+	assert.Less(t, CompareDN("dc=example,dc=com", "cn=test,dc=example,dc=com"), 0)
+	assert.Less(t, CompareDN("ou=a,dc=example,dc=com", "cn=test,ou=b,dc=example,dc=com"), 0)
+	assert.Greater(t, CompareDN("cn=b,dc=example,dc=com", "cn=a,dc=example,dc=com"), 0)
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := []*ldap.Entry{
+		ldap.NewEntry("cn=c,dc=example,dc=com", map[string][]string{"sn": {"Bar"}}),
+		ldap.NewEntry("cn=a,dc=example,dc=com", map[string][]string{}),
+		ldap.NewEntry("cn=b,dc=example,dc=com", map[string][]string{"sn": {"foo"}}),
+		ldap.NewEntry("dc=example,dc=com", map[string][]string{"sn": {"bar"}}),
+	}
+	dns := func() []string {
+		var dns []string
+		for _, entry := range entries {
+			dns = append(dns, entry.DN)
+		}
+		return dns
+	}
+
+	SortEntries(entries, nil)
+	assert.Equal(t, []string{"dc=example,dc=com", "cn=a,dc=example,dc=com", "cn=b,dc=example,dc=com", "cn=c,dc=example,dc=com"}, dns())
+
+	SortEntries(entries, []*ldap.SortKey{{AttributeType: "sn"}})
+	assert.Equal(t, []string{"dc=example,dc=com", "cn=c,dc=example,dc=com", "cn=b,dc=example,dc=com", "cn=a,dc=example,dc=com"}, dns())
+
+	SortEntries(entries, []*ldap.SortKey{{AttributeType: "SN", Reverse: true}})
+	assert.Equal(t, []string{"cn=b,dc=example,dc=com", "dc=example,dc=com", "cn=c,dc=example,dc=com", "cn=a,dc=example,dc=com"}, dns())
+}
+
+func TestFilterMatch(t *testing.T) {
+	filter := func(m FilterMatch) string {
+		f, err := m.Filter()
+		assert.NoError(t, err)
+		return f
+	}
+	assert.Equal(t, "(cn=a\\2ab\\28c\\29)", filter(FilterMatch{Attribute: "cn", Value: "a*b(c)"}))
+	assert.Equal(t, "(cn=*)", filter(FilterMatch{Attribute: "cn", Operator: "presence"}))
+	assert.Equal(t, "(cn=*a\\2a*)", filter(FilterMatch{Attribute: "cn", Operator: "substring", Value: "a*"}))
+	assert.Equal(t, "(cn=a*)", filter(FilterMatch{Attribute: "cn", Operator: "starts_with", Value: "a"}))
+	assert.Equal(t, "(cn=*a)", filter(FilterMatch{Attribute: "cn", Operator: "ends_with", Value: "a"}))
+	assert.Equal(t, "(uidNumber>=1000)", filter(FilterMatch{Attribute: "uidNumber", Operator: "greater_or_equal", Value: "1000"}))
+	assert.Equal(t, "(!(uidNumber<=1000))", filter(FilterMatch{Attribute: "uidNumber", Operator: "less_or_equal", Value: "1000", Not: true}))
+	assert.Equal(t, "(2.5.4.3;lang-de=a)", filter(FilterMatch{Attribute: "2.5.4.3;lang-de", Value: "a"}))
+
+	for _, attribute := range []string{"cn=a)(uid=*", "", "1cn", "2.", "cn;"} {
+		_, err := FilterMatch{Attribute: attribute, Value: "a"}.Filter()
+		assert.Error(t, err, attribute)
+	}
+}
+
+func TestCombineFilters(t *testing.T) {
+	assert.Equal(t, "(cn=a)", CombineFilters("or", "(cn=a)"))
+	assert.Equal(t, "(&(cn=a)(sn=b))", CombineFilters("", "(cn=a)", "(sn=b)"))
+	assert.Equal(t, "(|(cn=a)(sn=b))", CombineFilters("or", "(cn=a)", "(sn=b)"))
+}
+
+func TestRangedAttributeType(t *testing.T) {
+	attributeType, end, ok := RangedAttributeType("member;range=0-1499")
+	assert.True(t, ok)
+	assert.Equal(t, "member", attributeType)
+	assert.Equal(t, 1499, end)
+
+	attributeType, end, ok = RangedAttributeType("member;Range=1500-*;binary")
+	assert.True(t, ok)
+	assert.Equal(t, "member;binary", attributeType)
+	assert.Equal(t, -1, end)
+
+	_, _, ok = RangedAttributeType("member")
+	assert.False(t, ok)
+}
+
+// rangedSearcher returns the values of a multi-valued attribute in ranges of two values.
+type rangedSearcher struct {
+	values []string
+}
+
+func (s rangedSearcher) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	var start int
+	if _, err := fmt.Sscanf(searchRequest.Attributes[0], "member;range=%d-*", &start); err != nil {
+		return nil, err
+	}
+	end := start + 1
+	name := fmt.Sprintf("member;range=%d-%d", start, end)
+	if end >= len(s.values)-1 {
+		end = len(s.values) - 1
+		name = fmt.Sprintf("member;range=%d-*", start)
+	}
+	return &ldap.SearchResult{Entries: []*ldap.Entry{
+		ldap.NewEntry(searchRequest.BaseDN, map[string][]string{name: s.values[start : end+1]}),
+	}}, nil
+}
+
+func (s rangedSearcher) SearchWithPaging(searchRequest *ldap.SearchRequest, _ uint32) (*ldap.SearchResult, error) {
+	return s.Search(searchRequest)
+}
+
+func TestResolveRangedAttributes(t *testing.T) {
+	conn := rangedSearcher{values: []string{"cn=a", "cn=b", "cn=c", "cn=d", "cn=e"}}
+	entry := ldap.NewEntry("cn=group,dc=example,dc=com", map[string][]string{
+		"cn":               {"group"},
+		"member;range=0-1": {"cn=a", "cn=b"},
+	})
+	assert.NoError(t, ResolveRangedAttributes(conn, entry))
+	assert.Equal(t, []string{"cn=a", "cn=b", "cn=c", "cn=d", "cn=e"}, entry.GetAttributeValues("member"))
+	assert.Equal(t, []string{"group"}, entry.GetAttributeValues("cn"))
+}
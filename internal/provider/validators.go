@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = attributePatternValidator{}
+
+// attributePatternValidator validates that a string is a valid pattern for MatchAttributeType.
+type attributePatternValidator struct{}
+
+func (v attributePatternValidator) Description(_ context.Context) string {
+	return "value must be an attribute type, a glob pattern or a regular expression enclosed in slashes"
+}
+
+func (v attributePatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v attributePatternValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := MatchAttributeType(request.ConfigValue.ValueString(), ""); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid attribute pattern",
+			fmt.Sprintf("%s: %s", v.Description(ctx), err),
+		)
+	}
+}
+
+// AttributePattern returns a validator which ensures that a string is a valid attribute type pattern.
+func AttributePattern() validator.String {
+	return attributePatternValidator{}
+}
+
+var _ validator.String = filterValidator{}
+
+// filterValidator validates that a string is a valid LDAP search filter.
+type filterValidator struct{}
+
+func (v filterValidator) Description(_ context.Context) string {
+	return "value must be a valid LDAP search filter"
+}
+
+func (v filterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v filterValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := ldap.CompileFilter(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid filter",
+			fmt.Sprintf("%s: %s", v.Description(ctx), err),
+		)
+	}
+}
+
+// Filter returns a validator which ensures that a string is a valid LDAP search filter.
+func Filter() validator.String {
+	return filterValidator{}
+}